@@ -702,7 +702,7 @@ func (msg testSendMsg) GetSigners() []sdk.Address {
 func newHandleBurn(keeper bank.Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		burnMsg := msg.(testBurnMsg)
-		_, _, err := keeper.SubtractCoins(ctx, burnMsg.Addr, burnMsg.Amount)
+		_, _, err := keeper.BurnCoins(ctx, burnMsg.Addr, burnMsg.Amount)
 		if err != nil {
 			return err.Result()
 		}
@@ -713,11 +713,7 @@ func newHandleBurn(keeper bank.Keeper) sdk.Handler {
 func newHandleSpend(keeper bank.Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		spendMsg := msg.(testSendMsg)
-		_, _, err := keeper.SubtractCoins(ctx, spendMsg.Sender, spendMsg.Amount)
-		if err != nil {
-			return err.Result()
-		}
-		_, _, err = keeper.AddCoins(ctx, spendMsg.Receiver, spendMsg.Amount)
+		_, err := keeper.SendCoins(ctx, spendMsg.Sender, spendMsg.Receiver, spendMsg.Amount)
 		if err != nil {
 			return err.Result()
 		}
@@ -735,7 +731,7 @@ func GenTx(chainID string, msgs []sdk.Msg, accnums []int64, seq []int64, priv ..
 
 	sigs := make([]auth.StdSignature, len(priv))
 	for i, p := range priv {
-		sig, err := p.Sign(auth.StdSignBytes(chainID, accnums[i], seq[i], fee, msgs, ""))
+		sig, err := p.Sign(auth.StdSignBytes(chainID, accnums[i], seq[i], fee, msgs, "", 0, false))
 		// TODO: replace with proper error handling:
 		if err != nil {
 			panic(err)
@@ -747,7 +743,7 @@ func GenTx(chainID string, msgs []sdk.Msg, accnums []int64, seq []int64, priv ..
 			Sequence:      seq[i],
 		}
 	}
-	return auth.NewStdTx(msgs, fee, sigs, "")
+	return auth.NewStdTx(msgs, fee, sigs, "", 0, false)
 }
 
 // spin up simple app for testing
@@ -790,7 +786,7 @@ func TestMultipleBurn(t *testing.T) {
 	}
 
 	app.accountMapper = auth.NewAccountMapper(app.cdc, capKey, &auth.BaseAccount{})
-	app.accountKeeper = bank.NewKeeper(app.accountMapper)
+	app.accountKeeper = bank.NewKeeper(app.cdc, capKey, app.accountMapper)
 
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, auth.FeeCollectionKeeper{}))
 
@@ -807,7 +803,7 @@ func TestMultipleBurn(t *testing.T) {
 	priv := makePrivKey("my secret")
 	addr := priv.PubKey().Address()
 
-	app.accountKeeper.AddCoins(app.deliverState.ctx, addr, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
+	app.accountKeeper.MintCoins(app.deliverState.ctx, addr, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
 	require.Equal(t, sdk.Coins{{"foocoin", sdk.NewInt(100)}}, app.accountKeeper.GetCoins(app.deliverState.ctx, addr), "Balance did not update")
 
 	msg := testBurnMsg{addr, sdk.Coins{{"foocoin", sdk.NewInt(50)}}}
@@ -837,7 +833,7 @@ func TestBurnMultipleOwners(t *testing.T) {
 	}
 
 	app.accountMapper = auth.NewAccountMapper(app.cdc, capKey, &auth.BaseAccount{})
-	app.accountKeeper = bank.NewKeeper(app.accountMapper)
+	app.accountKeeper = bank.NewKeeper(app.cdc, capKey, app.accountMapper)
 
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, auth.FeeCollectionKeeper{}))
 
@@ -858,8 +854,8 @@ func TestBurnMultipleOwners(t *testing.T) {
 	addr2 := priv2.PubKey().Address()
 
 	// fund accounts
-	app.accountKeeper.AddCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
-	app.accountKeeper.AddCoins(app.deliverState.ctx, addr2, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
+	app.accountKeeper.MintCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
+	app.accountKeeper.MintCoins(app.deliverState.ctx, addr2, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
 
 	require.Equal(t, sdk.Coins{{"foocoin", sdk.NewInt(100)}}, app.accountKeeper.GetCoins(app.deliverState.ctx, addr1), "Balance1 did not update")
 	require.Equal(t, sdk.Coins{{"foocoin", sdk.NewInt(100)}}, app.accountKeeper.GetCoins(app.deliverState.ctx, addr2), "Balance2 did not update")
@@ -904,7 +900,7 @@ func TestSendBurn(t *testing.T) {
 	}
 
 	app.accountMapper = auth.NewAccountMapper(app.cdc, capKey, &auth.BaseAccount{})
-	app.accountKeeper = bank.NewKeeper(app.accountMapper)
+	app.accountKeeper = bank.NewKeeper(app.cdc, capKey, app.accountMapper)
 
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, auth.FeeCollectionKeeper{}))
 
@@ -925,7 +921,7 @@ func TestSendBurn(t *testing.T) {
 	addr2 := priv2.PubKey().Address()
 
 	// fund accounts
-	app.accountKeeper.AddCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
+	app.accountKeeper.MintCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
 	acc := app.accountMapper.NewAccountWithAddress(app.deliverState.ctx, addr2)
 	app.accountMapper.SetAccount(app.deliverState.ctx, acc)
 
@@ -946,7 +942,7 @@ func TestSendBurn(t *testing.T) {
 	require.Equal(t, sdk.Coins(nil), app.accountKeeper.GetCoins(app.deliverState.ctx, addr2), "Balance2 did not change after valid tx")
 
 	// Check that state is only updated if all msgs in tx pass.
-	app.accountKeeper.AddCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(50)}})
+	app.accountKeeper.MintCoins(app.deliverState.ctx, addr1, sdk.Coins{{"foocoin", sdk.NewInt(50)}})
 
 	// burn then send
 	tx = GenTx(t.Name(), []sdk.Msg{msg1, sendMsg}, []int64{0}, []int64{1}, priv1)
@@ -140,6 +140,8 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 	accnum := ctx.AccountNumber
 	sequence := ctx.Sequence
 	memo := ctx.Memo
+	timeoutHeight := ctx.TimeoutHeight
+	unordered := ctx.Unordered
 
 	signMsg := auth.StdSignMsg{
 		ChainID:       chainID,
@@ -147,6 +149,8 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 		Sequence:      sequence,
 		Msgs:          msgs,
 		Memo:          memo,
+		TimeoutHeight: timeoutHeight,
+		Unordered:     unordered,
 		Fee:           auth.NewStdFee(ctx.Gas, sdk.Coin{}), // TODO run simulate to estimate gas?
 	}
 
@@ -170,7 +174,7 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 	}}
 
 	// marshal bytes
-	tx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, memo)
+	tx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, memo, timeoutHeight, unordered)
 
 	return cdc.MarshalBinary(tx)
 }
@@ -17,6 +17,8 @@ type CoreContext struct {
 	AccountNumber   int64
 	Sequence        int64
 	Memo            string
+	TimeoutHeight   int64
+	Unordered       bool
 	Client          rpcclient.Client
 	Decoder         auth.AccountDecoder
 	AccountStore    string
@@ -78,6 +80,18 @@ func (c CoreContext) WithMemo(memo string) CoreContext {
 	return c
 }
 
+// WithTimeoutHeight - return a copy of the context with an updated timeout height
+func (c CoreContext) WithTimeoutHeight(timeoutHeight int64) CoreContext {
+	c.TimeoutHeight = timeoutHeight
+	return c
+}
+
+// WithUnordered - return a copy of the context with an updated unordered flag
+func (c CoreContext) WithUnordered(unordered bool) CoreContext {
+	c.Unordered = unordered
+	return c
+}
+
 // WithClient - return a copy of the context with an updated RPC client instance
 func (c CoreContext) WithClient(client rpcclient.Client) CoreContext {
 	c.Client = client
@@ -37,6 +37,8 @@ func NewCoreContextFromViper() CoreContext {
 		AccountNumber:   viper.GetInt64(client.FlagAccountNumber),
 		Sequence:        viper.GetInt64(client.FlagSequence),
 		Memo:            viper.GetString(client.FlagMemo),
+		TimeoutHeight:   viper.GetInt64(client.FlagTimeoutHeight),
+		Unordered:       viper.GetBool(client.FlagUnordered),
 		Client:          rpc,
 		Decoder:         nil,
 		AccountStore:    "acc",
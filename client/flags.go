@@ -15,6 +15,8 @@ const (
 	FlagSequence      = "sequence"
 	FlagMemo          = "memo"
 	FlagFee           = "fee"
+	FlagTimeoutHeight = "timeout-height"
+	FlagUnordered     = "unordered"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -42,6 +44,8 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Int64(FlagSequence, 0, "Sequence number to sign the tx")
 		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
 		c.Flags().String(FlagFee, "", "Fee to pay along with transaction")
+		c.Flags().Int64(FlagTimeoutHeight, 0, "Last block height the transaction can be included in, 0 for no timeout")
+		c.Flags().Bool(FlagUnordered, false, "Skip the sequence check, requires a timeout height")
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
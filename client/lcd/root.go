@@ -19,9 +19,13 @@ import (
 	tx "github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/wire"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
+	bankclient "github.com/cosmos/cosmos-sdk/x/bank/client"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/client/rest"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 	ibc "github.com/cosmos/cosmos-sdk/x/ibc/client/rest"
+	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
+	params "github.com/cosmos/cosmos-sdk/x/params/client/rest"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
 	stake "github.com/cosmos/cosmos-sdk/x/stake/client/rest"
 )
@@ -86,6 +90,10 @@ func createHandler(cdc *wire.Codec) http.Handler {
 	ibc.RegisterRoutes(ctx, r, cdc, kb)
 	stake.RegisterRoutes(ctx, r, cdc, kb)
 	slashing.RegisterRoutes(ctx, r, cdc, kb)
+	distribution.RegisterRoutes(ctx, r, cdc, kb)
 	gov.RegisterRoutes(ctx, r, cdc)
+	params.RegisterRoutes(ctx, r, cdc, "params", map[string]paramsclient.ModuleParamsQuerier{
+		"bank": bankclient.ParamsQuerier("bank"),
+	})
 	return r
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -14,11 +15,15 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
@@ -39,10 +44,16 @@ type GaiaApp struct {
 	// keys to access the substores
 	keyMain     *sdk.KVStoreKey
 	keyAccount  *sdk.KVStoreKey
+	keyBank     *sdk.KVStoreKey
 	keyIBC      *sdk.KVStoreKey
 	keyStake    *sdk.KVStoreKey
 	keySlashing *sdk.KVStoreKey
 	keyGov      *sdk.KVStoreKey
+	keyAuthz    *sdk.KVStoreKey
+	keyFee      *sdk.KVStoreKey
+	keyDistr    *sdk.KVStoreKey
+	keyParams   *sdk.KVStoreKey
+	keyUpgrade  *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
@@ -52,6 +63,10 @@ type GaiaApp struct {
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
 	govKeeper           gov.Keeper
+	authzKeeper         authz.Keeper
+	distrKeeper         distribution.Keeper
+	paramsKeeper        params.Keeper
+	upgradeKeeper       upgrade.Keeper
 }
 
 func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
@@ -63,25 +78,57 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 		cdc:         cdc,
 		keyMain:     sdk.NewKVStoreKey("main"),
 		keyAccount:  sdk.NewKVStoreKey("acc"),
+		keyBank:     sdk.NewKVStoreKey("bank"),
 		keyIBC:      sdk.NewKVStoreKey("ibc"),
 		keyStake:    sdk.NewKVStoreKey("stake"),
 		keySlashing: sdk.NewKVStoreKey("slashing"),
 		keyGov:      sdk.NewKVStoreKey("gov"),
+		keyAuthz:    sdk.NewKVStoreKey("authz"),
+		keyFee:      sdk.NewKVStoreKey("fee"),
+		keyDistr:    sdk.NewKVStoreKey("distr"),
+		keyParams:   sdk.NewKVStoreKey("params"),
+		keyUpgrade:  sdk.NewKVStoreKey("upgrade"),
 	}
 
+	// the params keeper hands out the parameter subspaces of the modules
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+
 	// define the accountMapper
 	app.accountMapper = auth.NewAccountMapper(
 		app.cdc,
 		app.keyAccount,      // target store
 		&auth.BaseAccount{}, // prototype
-	)
+	).WithParamSpace(app.paramsKeeper.Subspace(auth.DefaultParamspace))
 
 	// add handlers
-	app.coinKeeper = bank.NewKeeper(app.accountMapper)
+	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFee)
+	app.coinKeeper = bank.NewKeeper(app.cdc, app.keyBank, app.accountMapper).WithModuleAccounts(map[string][]string{
+		auth.FeeCollectorName:   nil,
+		stake.ModuleName:        {auth.Minter, auth.Burner, auth.Staking},
+		gov.ModuleName:          {auth.Burner},
+		distribution.ModuleName: nil,
+	}).WithBlockedAddrs(
+		// module accounts only receive coins through their modules
+		auth.NewModuleAddress(auth.FeeCollectorName),
+		auth.NewModuleAddress(stake.ModuleName),
+		auth.NewModuleAddress(gov.ModuleName),
+		auth.NewModuleAddress(distribution.ModuleName),
+	)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.RegisterCodespace(slashing.DefaultCodespace))
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.paramsKeeper.Subspace(stake.DefaultParamspace), app.RegisterCodespace(stake.DefaultCodespace))
+	app.coinKeeper = app.coinKeeper.WithDelegationSet(app.stakeKeeper)
+	app.distrKeeper = distribution.NewKeeper(app.cdc, app.keyDistr, app.coinKeeper, app.stakeKeeper, app.feeCollectionKeeper, app.paramsKeeper.Subspace(distribution.DefaultParamspace), app.RegisterCodespace(distribution.DefaultCodespace))
+	app.stakeKeeper = app.stakeKeeper.WithHooks(app.distrKeeper.Hooks())
+	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Subspace(slashing.DefaultParamspace), app.RegisterCodespace(slashing.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.paramsKeeper.Subspace(gov.DefaultParamspace), app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+	app.govKeeper = app.govKeeper.WithParamChangeHandlers(app.paramChangeHandlers()).
+		WithCommunityPoolSpender(app.distrKeeper)
+
+	// the handlers of the upgrades implemented by this binary are registered
+	// on the upgrade keeper with SetUpgradeHandler
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade, app.RegisterCodespace(upgrade.DefaultCodespace))
+	app.govKeeper = app.govKeeper.WithUpgradeScheduler(app.upgradeKeeper)
+	app.authzKeeper = authz.NewKeeper(app.cdc, app.keyAuthz, app.Router(), app.RegisterCodespace(authz.DefaultCodespace))
 
 	// register message routes
 	app.Router().
@@ -89,14 +136,16 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("authz", authz.NewHandler(app.authzKeeper)).
+		AddRoute("distribution", distribution.NewHandler(app.distrKeeper))
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyBank, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyAuthz, app.keyFee, app.keyDistr, app.keyParams, app.keyUpgrade)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -113,6 +162,8 @@ func MakeCodec() *wire.Codec {
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
 	gov.RegisterWire(cdc)
+	authz.RegisterWire(cdc)
+	distribution.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
@@ -121,7 +172,12 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
-	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+	// halts at the height of a scheduled upgrade unless this binary implements it
+	tags := upgrade.BeginBlocker(ctx, app.upgradeKeeper)
+
+	distribution.BeginBlocker(ctx, req, app.distrKeeper)
+
+	tags = tags.AppendTags(slashing.BeginBlocker(ctx, req, app.slashingKeeper))
 
 	return abci.ResponseBeginBlock{
 		Tags: tags.ToKVPairs(),
@@ -133,7 +189,9 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
 
-	tags, _ := gov.EndBlocker(ctx, app.govKeeper)
+	tags := gov.EndBlocker(ctx, app.govKeeper)
+
+	app.accountMapper.PruneUnorderedTxs(ctx)
 
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
@@ -141,6 +199,46 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	}
 }
 
+// CheckInvariants checks that the coins held by accounts add up to the
+// recorded supply, and that the module accounts hold what the modules escrow
+func (app *GaiaApp) CheckInvariants(ctx sdk.Context) error {
+	err := bank.SupplyInvariant(ctx, app.coinKeeper)
+	if err != nil {
+		return err
+	}
+
+	escrows := map[string]sdk.Coins{
+		stake.ModuleName: app.stakeKeeper.GetHeldTokens(ctx),
+		gov.ModuleName:   app.govKeeper.GetDepositedCoins(ctx),
+	}
+	for name, escrowed := range escrows {
+		held := app.coinKeeper.GetModuleCoins(ctx, name)
+		if !held.Minus(escrowed).IsZero() {
+			return fmt.Errorf("module account %s holds %v but the module escrows %v", name, held, escrowed)
+		}
+	}
+	return nil
+}
+
+// handlers of the parameter changes proposed to governance, keyed by subspace
+func (app *GaiaApp) paramChangeHandlers() map[string]gov.ParamChangeHandler {
+	handlers := map[string]gov.ParamChangeHandler{
+		"bank": app.paramsKeeper.WithHistory("bank", app.coinKeeper),
+		// stake params are changed through the keeper to update the bonded validators
+		stake.DefaultParamspace: app.stakeKeeper,
+		// the distribution fractions are checked together
+		distribution.DefaultParamspace: app.distrKeeper,
+	}
+	for _, name := range []string{auth.DefaultParamspace, slashing.DefaultParamspace, gov.DefaultParamspace} {
+		subspace, ok := app.paramsKeeper.GetSubspace(name)
+		if !ok {
+			panic(fmt.Sprintf("missing params subspace %s", name))
+		}
+		handlers[name] = subspace
+	}
+	return handlers
+}
+
 // custom logic for gaia initialization
 func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 	stateJSON := req.AppStateBytes
@@ -153,17 +251,43 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		// return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
 
+	auth.InitGenesis(ctx, app.accountMapper, genesisState.AuthData)
+
 	// load the accounts
+	supply := sdk.Coins{}
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
 		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
 		app.accountMapper.SetAccount(ctx, acc)
+		supply = supply.Plus(acc.GetCoins())
 	}
 
 	// load the initial stake information
 	stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 
-	gov.InitGenesis(ctx, app.govKeeper, gov.DefaultGenesisState())
+	app.coinKeeper.SetSupply(ctx, supply)
+	bank.InitGenesis(ctx, app.coinKeeper, genesisState.BankData)
+
+	// the bank params are kept in the bank store, their genesis values start
+	// their history
+	app.coinKeeper.IterateParams(ctx, func(key string, value string) (stop bool) {
+		app.paramsKeeper.RecordParamChange(ctx, "bank", key, value)
+		return false
+	})
+
+	// the tokens bonded at genesis are held by the stake module account
+	bonded := app.stakeKeeper.GetHeldTokens(ctx)
+	if !bonded.IsZero() {
+		_, err = app.coinKeeper.MintModuleCoins(ctx, stake.ModuleName, bonded)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData)
+	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
+	distribution.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
+	upgrade.InitGenesis(ctx, app.upgradeKeeper, genesisState.UpgradeData)
 
 	return abci.ResponseInitChain{}
 }
@@ -175,6 +299,12 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	// iterate to get the accounts
 	accounts := []GenesisAccount{}
 	appendAccount := func(acc auth.Account) (stop bool) {
+		// the stake module account is recreated from the tokens held in the
+		// stake state, the balances of the other module accounts (collected
+		// fees, deposits, rewards not yet withdrawn) are exported as they are
+		if macc, ok := acc.(*auth.ModuleAccount); ok && macc.Name == stake.ModuleName {
+			return false
+		}
 		account := NewGenesisAccountI(acc)
 		accounts = append(accounts, account)
 		return false
@@ -182,8 +312,14 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+		Accounts:     accounts,
+		AuthData:     auth.WriteGenesis(ctx, app.accountMapper),
+		BankData:     bank.WriteGenesis(ctx, app.coinKeeper),
+		StakeData:    stake.WriteGenesis(ctx, app.stakeKeeper),
+		SlashingData: slashing.WriteGenesis(ctx, app.slashingKeeper),
+		GovData:      gov.WriteGenesis(ctx, app.govKeeper),
+		DistrData:    distribution.WriteGenesis(ctx, app.distrKeeper),
+		UpgradeData:  upgrade.WriteGenesis(ctx, app.upgradeKeeper),
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
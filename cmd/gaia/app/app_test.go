@@ -1,11 +1,24 @@
 package app
 
 import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
 )
 
 func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
@@ -15,8 +28,14 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	}
 
 	genesisState := GenesisState{
-		Accounts:  genaccs,
-		StakeData: stake.DefaultGenesisState(),
+		Accounts:     genaccs,
+		AuthData:     auth.DefaultGenesisState(),
+		BankData:     bank.DefaultGenesisState(),
+		StakeData:    stake.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		DistrData:    distribution.DefaultGenesisState(),
+		UpgradeData:  upgrade.DefaultGenesisState(),
 	}
 
 	stateBytes, err := wire.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -31,3 +50,78 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 
 	return nil
 }
+
+func TestCheckInvariants(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+
+	priv1, priv2 := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+	addr1, addr2 := sdk.Address(priv1.PubKey().Address()), sdk.Address(priv2.PubKey().Address())
+	acc1 := auth.NewBaseAccountWithAddress(addr1)
+	acc1.Coins = sdk.Coins{sdk.NewCoin("steak", 100)}
+	require.NoError(t, setGenesis(gapp, &acc1))
+
+	header := abci.Header{Height: 1}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := gapp.NewContext(false, header)
+	require.NoError(t, gapp.CheckInvariants(ctx))
+
+	// sends
+	_, err := gapp.coinKeeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewCoin("steak", 10)})
+	require.Nil(t, err)
+	require.NoError(t, gapp.CheckInvariants(ctx))
+
+	// bonds and slashes
+	msg := stake.NewMsgCreateValidator(addr1, priv1.PubKey(), sdk.NewCoin("steak", 50), stake.Description{})
+	res := stake.NewHandler(gapp.stakeKeeper)(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.NoError(t, gapp.CheckInvariants(ctx))
+	gapp.stakeKeeper.Slash(ctx, priv1.PubKey(), ctx.BlockHeight(), 50, sdk.NewRat(1, 2))
+	require.NoError(t, gapp.CheckInvariants(ctx))
+
+	// burns
+	_, _, err = gapp.coinKeeper.BurnCoins(ctx, addr2, sdk.Coins{sdk.NewCoin("steak", 5)})
+	require.Nil(t, err)
+	require.NoError(t, gapp.CheckInvariants(ctx))
+
+	// coins appearing without being minted break the supply
+	acc := gapp.accountMapper.GetAccount(ctx, addr2)
+	require.Nil(t, acc.SetCoins(acc.GetCoins().Plus(sdk.Coins{sdk.NewCoin("steak", 5)})))
+	gapp.accountMapper.SetAccount(ctx, acc)
+	require.Error(t, gapp.CheckInvariants(ctx))
+}
+
+func TestExportModuleBalances(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+
+	priv1 := crypto.GenPrivKeyEd25519()
+	addr1 := sdk.Address(priv1.PubKey().Address())
+	acc1 := auth.NewBaseAccountWithAddress(addr1)
+	acc1.Coins = sdk.Coins{sdk.NewCoin("steak", 100)}
+	require.NoError(t, setGenesis(gapp, &acc1))
+
+	header := abci.Header{Height: 1}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := gapp.NewContext(false, header)
+
+	// fees collected in the block and a deposit escrowed by gov
+	_, err := gapp.coinKeeper.SendCoinsFromAccountToModule(ctx, addr1, auth.FeeCollectorName, sdk.Coins{sdk.NewCoin("steak", 5)})
+	require.Nil(t, err)
+	msg := gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addr1, sdk.Coins{sdk.NewCoin("steak", 10)})
+	res := gov.NewHandler(gapp.govKeeper)(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	gapp.EndBlock(abci.RequestEndBlock{})
+	gapp.Commit()
+
+	appState, _, err := gapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+
+	// importing the exported state keeps the coins of the module accounts
+	gapp2 := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	gapp2.InitChain(abci.RequestInitChain{Validators: []abci.Validator{}, AppStateBytes: appState})
+	gapp2.Commit()
+	ctx2 := gapp2.NewContext(true, abci.Header{})
+	require.NoError(t, gapp2.CheckInvariants(ctx2))
+	require.True(t, gapp2.coinKeeper.GetSupply(ctx2).IsEqual(sdk.Coins{sdk.NewCoin("steak", 100)}))
+	require.True(t, gapp2.coinKeeper.GetModuleCoins(ctx2, auth.FeeCollectorName).IsEqual(sdk.Coins{sdk.NewCoin("steak", 5)}))
+	require.True(t, gapp2.coinKeeper.GetModuleCoins(ctx2, gov.ModuleName).IsEqual(sdk.Coins{sdk.NewCoin("steak", 10)}))
+}
@@ -13,7 +13,12 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -24,8 +29,14 @@ var (
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount   `json:"accounts"`
-	StakeData stake.GenesisState `json:"stake"`
+	Accounts     []GenesisAccount          `json:"accounts"`
+	AuthData     auth.GenesisState         `json:"auth"`
+	BankData     bank.GenesisState         `json:"bank"`
+	StakeData    stake.GenesisState        `json:"stake"`
+	SlashingData slashing.GenesisState     `json:"slashing"`
+	GovData      gov.GenesisState          `json:"gov"`
+	DistrData    distribution.GenesisState `json:"distribution"`
+	UpgradeData  upgrade.GenesisState      `json:"upgrade"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
@@ -172,6 +183,7 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 			// add some new shares to the validator
 			var issuedDelShares sdk.Rat
 			validator, stakeData.Pool, issuedDelShares = validator.AddTokensFromDel(stakeData.Pool, freeFermionVal)
+			validator.SelfDelegationShares = issuedDelShares
 			stakeData.Validators = append(stakeData.Validators, validator)
 
 			// create the self-delegation from the issuedDelShares
@@ -188,8 +200,14 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		StakeData: stakeData,
+		Accounts:     genaccs,
+		AuthData:     auth.DefaultGenesisState(),
+		BankData:     bank.DefaultGenesisState(),
+		StakeData:    stakeData,
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		DistrData:    distribution.DefaultGenesisState(),
+		UpgradeData:  upgrade.DefaultGenesisState(),
 	}
 	return
 }
@@ -12,11 +12,16 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	bankclient "github.com/cosmos/cosmos-sdk/x/bank/client"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
+	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
+	paramscmd "github.com/cosmos/cosmos-sdk/x/params/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
+	upgradecmd "github.com/cosmos/cosmos-sdk/x/upgrade/client/cli"
 
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 )
@@ -86,9 +91,11 @@ func main() {
 		client.GetCommands(
 			stakecmd.GetCmdQueryValidator("stake", cdc),
 			stakecmd.GetCmdQueryValidators("stake", cdc),
+			stakecmd.GetCmdQuerySelfBond("stake", cdc),
 			stakecmd.GetCmdQueryDelegation("stake", cdc),
 			stakecmd.GetCmdQueryDelegations("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
+			distrcmd.GetCmdQueryCommunityPool("distr", cdc),
 		)...)
 	stakeCmd.AddCommand(
 		client.PostCommands(
@@ -98,6 +105,8 @@ func main() {
 			stakecmd.GetCmdUnbond("stake", cdc),
 			stakecmd.GetCmdRedelegate("stake", cdc),
 			slashingcmd.GetCmdUnrevoke(cdc),
+			distrcmd.GetCmdWithdrawDelegatorReward(cdc),
+			distrcmd.GetCmdWithdrawValidatorCommission(cdc),
 		)...)
 	rootCmd.AddCommand(
 		stakeCmd,
@@ -112,12 +121,19 @@ func main() {
 		client.GetCommands(
 			govcmd.GetCmdQueryProposal("gov", cdc),
 			govcmd.GetCmdQueryVote("gov", cdc),
+			govcmd.GetCmdQueryTally("gov", "stake", cdc),
+			upgradecmd.GetCmdQueryPlan("upgrade", cdc),
+			paramscmd.GetCmdQueryParams("params", cdc, map[string]paramsclient.ModuleParamsQuerier{
+				"bank": bankclient.ParamsQuerier("bank"),
+			}),
+			paramscmd.GetCmdQueryParamHistory("params", cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			govcmd.GetCmdVote(cdc),
+			govcmd.GetCmdCancelProposal(cdc),
 		)...)
 	rootCmd.AddCommand(
 		govCmd,
@@ -127,10 +143,16 @@ func main() {
 	rootCmd.AddCommand(
 		client.GetCommands(
 			authcmd.GetAccountCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetAccountByNumberCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetAccountByPubKeyCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
+			bankcmd.GetSupplyCmd("bank", cdc),
+			bankcmd.GetDenomMetadataCmd("bank", cdc),
+			bankcmd.GetBalanceCmd("acc", "bank", cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.CloseAccountCmd(cdc),
 		)...)
 
 	// add proxy, version and key info
@@ -23,13 +23,14 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 	// Create the base application object.
 	app := bapp.NewBaseApp(app3Name, cdc, logger, db)
 
-	// Create a key for accessing the account store.
+	// Create keys for accessing the account and bank stores.
 	keyAccount := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
 	keyFees := sdk.NewKVStoreKey("fee") // TODO
 
 	// Set various mappers/keepers to interact easily with underlying stores
 	accountMapper := auth.NewAccountMapper(cdc, keyAccount, &auth.BaseAccount{})
-	coinKeeper := bank.NewKeeper(accountMapper)
+	coinKeeper := bank.NewKeeper(cdc, keyBank, accountMapper)
 	feeKeeper := auth.NewFeeCollectionKeeper(cdc, keyFees)
 
 	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper))
@@ -40,7 +41,7 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 		AddRoute("send", bank.NewHandler(coinKeeper))
 
 	// Mount stores and load the latest state.
-	app.MountStoresIAVL(keyAccount, keyFees)
+	app.MountStoresIAVL(keyAccount, keyBank, keyFees)
 	err := app.LoadLatestVersion(keyAccount)
 	if err != nil {
 		cmn.Exit(err.Error())
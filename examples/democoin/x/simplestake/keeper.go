@@ -66,7 +66,8 @@ func (k Keeper) Bond(ctx sdk.Context, addr sdk.Address, pubKey crypto.PubKey, st
 		return 0, ErrIncorrectStakingToken(k.codespace)
 	}
 
-	_, _, err := k.ck.SubtractCoins(ctx, addr, []sdk.Coin{stake})
+	// the bonded coins are taken out of the supply until unbonded
+	_, _, err := k.ck.BurnCoins(ctx, addr, []sdk.Coin{stake})
 	if err != nil {
 		return 0, err
 	}
@@ -95,7 +96,7 @@ func (k Keeper) Unbond(ctx sdk.Context, addr sdk.Address) (crypto.PubKey, int64,
 
 	returnedBond := sdk.NewCoin(stakingToken, bi.Power)
 
-	_, _, err := k.ck.AddCoins(ctx, addr, []sdk.Coin{returnedBond})
+	_, _, err := k.ck.MintCoins(ctx, addr, []sdk.Coin{returnedBond})
 	if err != nil {
 		return bi.PubKey, bi.Power, err
 	}
@@ -54,6 +54,7 @@ const (
 	CodeInvalidCoins      CodeType = 11
 	CodeOutOfGas          CodeType = 12
 	CodeMemoTooLarge      CodeType = 13
+	CodeTxTimeout         CodeType = 14
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -94,6 +95,8 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "out of gas"
 	case CodeMemoTooLarge:
 		return "memo too large"
+	case CodeTxTimeout:
+		return "tx timed out"
 	default:
 		return fmt.Sprintf("unknown code %d", code)
 	}
@@ -143,6 +146,9 @@ func ErrOutOfGas(msg string) Error {
 func ErrMemoTooLarge(msg string) Error {
 	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
 }
+func ErrTxTimeout(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxTimeout, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
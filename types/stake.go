@@ -87,3 +87,16 @@ type DelegationSet interface {
 	IterateDelegations(ctx Context, delegator Address,
 		fn func(index int64, delegation Delegation) (stop bool))
 }
+
+//_______________________________________________________________________________
+
+// event hooks for the changes of validators and delegations, used by the
+// modules keeping state per delegation
+type StakingHooks interface {
+	// called before the shares of an existing delegation change
+	BeforeDelegationSharesModified(ctx Context, delAddr Address, valAddr Address)
+	// called once a delegation has been created, modified or removed
+	AfterDelegationSharesModified(ctx Context, delAddr Address, valAddr Address)
+	// called before a validator without delegator shares is removed
+	BeforeValidatorRemoved(ctx Context, valAddr Address)
+}
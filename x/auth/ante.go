@@ -8,15 +8,13 @@ import (
 )
 
 const (
-	deductFeesCost    sdk.Gas = 10
-	memoCostPerByte   sdk.Gas = 1
-	verifyCost                = 100
-	maxMemoCharacters         = 100
+	deductFeesCost sdk.Gas = 10
 )
 
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
+// Unordered txs are deduplicated by hash instead of checking sequences.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 
 	return func(
@@ -37,19 +35,43 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 				true
 		}
 
+		params := am.GetParams(ctx)
 		memo := stdTx.GetMemo()
 
-		if len(memo) > maxMemoCharacters {
+		if int64(len(memo)) > params.MaxMemoCharacters {
 			return ctx,
-				sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters", maxMemoCharacters, len(memo))).Result(),
+				sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters", params.MaxMemoCharacters, len(memo))).Result(),
 				true
 		}
 
+		// reject txs past their timeout height
+		timeoutHeight := stdTx.GetTimeoutHeight()
+		if timeoutHeight > 0 && ctx.BlockHeight() > timeoutHeight {
+			return ctx,
+				sdk.ErrTxTimeout(fmt.Sprintf("tx timed out at height %d, current height is %d", timeoutHeight, ctx.BlockHeight())).Result(),
+				true
+		}
+
+		// unordered txs are only remembered until their timeout height
+		unordered := stdTx.IsUnordered()
+		if unordered {
+			if timeoutHeight == 0 {
+				return ctx,
+					sdk.ErrTxTimeout("unordered tx must set a timeout height").Result(),
+					true
+			}
+			if timeoutHeight > ctx.BlockHeight()+params.MaxUnorderedTimeoutDelta {
+				return ctx,
+					sdk.ErrTxTimeout(fmt.Sprintf("unordered tx timeout height can be at most %d blocks ahead", params.MaxUnorderedTimeoutDelta)).Result(),
+					true
+			}
+		}
+
 		// set the gas meter
 		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
 
 		// charge gas for the memo
-		ctx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(memo)), "memo")
+		ctx.GasMeter().ConsumeGas(params.MemoCostPerByte*sdk.Gas(len(memo)), "memo")
 
 		msgs := tx.GetMsgs()
 
@@ -73,15 +95,26 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 		fee := stdTx.Fee
 
 		// Check sig and nonce and collect signer accounts.
+		var unorderedHash []byte
 		var signerAccs = make([]Account, len(signerAddrs))
 		for i := 0; i < len(sigs); i++ {
 			signerAddr, sig := signerAddrs[i], sigs[i]
+			signBytes := StdSignBytes(ctx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo(), timeoutHeight, unordered)
+
+			// reject replays of unordered txs
+			if unordered && i == 0 {
+				unorderedHash = unorderedTxHash(signBytes)
+				if am.HasUnorderedTx(ctx, unorderedHash) {
+					return ctx,
+						sdk.ErrUnauthorized("unordered tx has already been processed").Result(),
+						true
+				}
+			}
 
 			// check signature, return account with incremented nonce
-			signBytes := StdSignBytes(ctx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
 			signerAcc, res := processSig(
 				ctx, am,
-				signerAddr, sig, signBytes,
+				signerAddr, sig, signBytes, unordered, params.SigVerifyCost,
 			)
 			if !res.IsOK() {
 				return ctx, res, true
@@ -97,6 +130,7 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 						return ctx, res, true
 					}
 					fck.addCollectedFees(ctx, fee.Amount)
+					collectFees(ctx, am, fee.Amount)
 				}
 			}
 
@@ -105,6 +139,10 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 			signerAccs[i] = signerAcc
 		}
 
+		if unordered {
+			am.setUnorderedTx(ctx, unorderedHash, timeoutHeight)
+		}
+
 		// cache the signer accounts in the context
 		ctx = WithSigners(ctx, signerAccs)
 
@@ -114,11 +152,11 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 	}
 }
 
-// verify the signature and increment the sequence.
+// verify the signature and increment the sequence, unless the tx is unordered.
 // if the account doesn't have a pubkey, set it.
 func processSig(
 	ctx sdk.Context, am AccountMapper,
-	addr sdk.Address, sig StdSignature, signBytes []byte) (
+	addr sdk.Address, sig StdSignature, signBytes []byte, unordered bool, sigVerifyCost sdk.Gas) (
 	acc Account, res sdk.Result) {
 
 	// Get the account.
@@ -135,15 +173,18 @@ func processSig(
 	}
 
 	// Check and increment sequence number.
-	seq := acc.GetSequence()
-	if seq != sig.Sequence {
-		return nil, sdk.ErrInvalidSequence(
-			fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq)).Result()
-	}
-	err := acc.SetSequence(seq + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
+	var err error
+	if !unordered {
+		seq := acc.GetSequence()
+		if seq != sig.Sequence {
+			return nil, sdk.ErrInvalidSequence(
+				fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq)).Result()
+		}
+		err = acc.SetSequence(seq + 1)
+		if err != nil {
+			// Handle w/ #870
+			panic(err)
+		}
 	}
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
@@ -164,7 +205,7 @@ func processSig(
 	}
 
 	// Check sig.
-	ctx.GasMeter().ConsumeGas(verifyCost, "ante verify")
+	ctx.GasMeter().ConsumeGas(sigVerifyCost, "ante verify")
 	if !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -192,5 +233,12 @@ func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
 	return acc, sdk.Result{}
 }
 
+// Hold the collected fees in the fee collector module account.
+func collectFees(ctx sdk.Context, am AccountMapper, fees sdk.Coins) {
+	feeCollector := am.GetModuleAccount(ctx, FeeCollectorName)
+	feeCollector.SetCoins(feeCollector.GetCoins().Plus(fees))
+	am.SetAccount(ctx, feeCollector)
+}
+
 // BurnFeeHandler burns all fees (decreasing total supply)
 func BurnFeeHandler(_ sdk.Context, _ sdk.Tx, _ sdk.Coins) {}
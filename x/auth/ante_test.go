@@ -67,28 +67,56 @@ func checkInvalidTx(t *testing.T, anteHandler sdk.AnteHandler, ctx sdk.Context,
 func newTestTx(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
-		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, "")
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, "", 0, false)
 		sig, err := priv.Sign(signBytes)
 		if err != nil {
 			panic(err)
 		}
 		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
 	}
-	tx := NewStdTx(msgs, fee, sigs, "")
+	tx := NewStdTx(msgs, fee, sigs, "", 0, false)
 	return tx
 }
 
 func newTestTxWithMemo(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, memo string) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
-		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, memo)
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, memo, 0, false)
 		sig, err := priv.Sign(signBytes)
 		if err != nil {
 			panic(err)
 		}
 		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
 	}
-	tx := NewStdTx(msgs, fee, sigs, memo)
+	tx := NewStdTx(msgs, fee, sigs, memo, 0, false)
+	return tx
+}
+
+func newTestTxWithTimeoutHeight(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, timeoutHeight int64) sdk.Tx {
+	sigs := make([]StdSignature, len(privs))
+	for i, priv := range privs {
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, "", timeoutHeight, false)
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
+	}
+	tx := NewStdTx(msgs, fee, sigs, "", timeoutHeight, false)
+	return tx
+}
+
+func newTestTxUnordered(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, memo string, timeoutHeight int64) sdk.Tx {
+	sigs := make([]StdSignature, len(privs))
+	for i, priv := range privs {
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, memo, timeoutHeight, true)
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
+	}
+	tx := NewStdTx(msgs, fee, sigs, memo, timeoutHeight, true)
 	return tx
 }
 
@@ -102,7 +130,7 @@ func newTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []in
 		}
 		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNums[i], Sequence: seqs[i]}
 	}
-	tx := NewStdTx(msgs, fee, sigs, memo)
+	tx := NewStdTx(msgs, fee, sigs, memo, 0, false)
 	return tx
 }
 
@@ -336,6 +364,7 @@ func TestAnteHandlerFees(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
+	require.True(t, mapper.GetModuleAccount(ctx, FeeCollectorName).GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
 }
 
 // Test logic around memo gas consumption.
@@ -382,6 +411,111 @@ func TestAnteHandlerMemoGas(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx)
 }
 
+func TestAnteHandlerTimeoutHeight(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 10}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+
+	// tx timed out
+	tx = newTestTxWithTimeoutHeight(ctx, msgs, privs, accnums, seqs, fee, 9)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeTxTimeout)
+
+	// the timeout height is covered by the signature
+	tx = newTestTxWithTimeoutHeight(ctx, msgs, privs, accnums, seqs, fee, 10)
+	stdTx := tx.(StdTx)
+	stdTx.TimeoutHeight = 11
+	checkInvalidTx(t, anteHandler, ctx, stdTx, sdk.CodeUnauthorized)
+
+	// tx can be included up to its timeout height
+	tx = newTestTxWithTimeoutHeight(ctx, msgs, privs, accnums, seqs, fee, 10)
+	checkValidTx(t, anteHandler, ctx, tx)
+
+	// no timeout
+	seqs = []int64{1}
+	tx = newTestTxWithTimeoutHeight(ctx, msgs, privs, accnums, seqs, fee, 0)
+	checkValidTx(t, anteHandler, ctx, tx)
+}
+
+func TestAnteHandlerUnordered(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 10}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+
+	// unordered txs require a bounded timeout height
+	tx = newTestTxUnordered(ctx, msgs, privs, accnums, seqs, fee, "", 0)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeTxTimeout)
+	tx = newTestTxUnordered(ctx, msgs, privs, accnums, seqs, fee, "", 11+DefaultParams().MaxUnorderedTimeoutDelta)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeTxTimeout)
+
+	// several unordered txs with the same sequence are accepted
+	tx = newTestTxUnordered(ctx, msgs, privs, accnums, seqs, fee, "first", 12)
+	checkValidTx(t, anteHandler, ctx, tx)
+	tx2 := newTestTxUnordered(ctx, msgs, privs, accnums, seqs, fee, "second", 12)
+	checkValidTx(t, anteHandler, ctx, tx2)
+
+	// the sequence isn't incremented
+	seq, err := mapper.GetSequence(ctx, addr1)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), seq)
+
+	// replays are rejected
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+
+	// the hashes are kept until the timeout height
+	mapper.PruneUnorderedTxs(ctx.WithBlockHeight(11))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+
+	// then pruned, by which time the tx itself has timed out
+	ctx = ctx.WithBlockHeight(12)
+	mapper.PruneUnorderedTxs(ctx)
+	require.False(t, mapper.HasUnorderedTx(ctx, unorderedTxHash(StdSignBytes(ctx.ChainID(), 0, 0, fee, msgs, "first", 12, true))))
+	require.False(t, mapper.HasUnorderedTx(ctx, unorderedTxHash(StdSignBytes(ctx.ChainID(), 0, 0, fee, msgs, "second", 12, true))))
+	checkInvalidTx(t, anteHandler, ctx.WithBlockHeight(13), tx, sdk.CodeTxTimeout)
+
+	// ordered txs still use the sequence
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidSequence)
+}
+
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	// setup
 	ms, capKey, capKey2 := setupMultiStore()
@@ -494,7 +628,7 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 		tx := newTestTxWithSignBytes(
 
 			msgs, privs, accnums, seqs, fee,
-			StdSignBytes(cs.chainID, cs.accnum, cs.seq, cs.fee, cs.msgs, ""),
+			StdSignBytes(cs.chainID, cs.accnum, cs.seq, cs.fee, cs.msgs, "", 0, false),
 			"",
 		)
 		checkInvalidTx(t, anteHandler, ctx, tx, cs.code)
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -74,3 +75,76 @@ func GetAccountCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecode
 		},
 	}
 }
+
+// GetAccountByNumberCmd returns a query account that will display the
+// state of the account with a given account number
+func GetAccountByNumberCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	return &cobra.Command{
+		Use:   "account-by-number [number]",
+		Short: "Query account by account number",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accNumber, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			return printIndexedAccount(ctx, storeName, cdc, decoder, auth.AccountNumberStoreKey(accNumber),
+				"No account with number "+args[0]+" was found in the state.")
+		},
+	}
+}
+
+// GetAccountByPubKeyCmd returns a query account that will display the
+// state of the account which has set a given public key
+func GetAccountByPubKeyCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	return &cobra.Command{
+		Use:   "account-by-pubkey [pubkey]",
+		Short: "Query account by bech32 public key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pubKey, err := sdk.GetAccPubKeyBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			return printIndexedAccount(ctx, storeName, cdc, decoder, auth.PubKeyStoreKey(pubKey),
+				"No account with public key "+args[0]+" was found in the state.")
+		},
+	}
+}
+
+// resolve an account address through a secondary index and print the account
+func printIndexedAccount(ctx context.CoreContext, storeName string, cdc *wire.Codec,
+	decoder auth.AccountDecoder, indexKey []byte, notFound string) error {
+
+	addr, err := ctx.QueryStore(indexKey, storeName)
+	if err != nil {
+		return err
+	}
+	if addr == nil {
+		return sdk.ErrUnknownAddress(notFound)
+	}
+
+	res, err := ctx.QueryStore(auth.AddressStoreKey(addr), storeName)
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return sdk.ErrUnknownAddress(notFound)
+	}
+
+	account, err := decoder(res)
+	if err != nil {
+		return err
+	}
+
+	output, err := wire.MarshalJSONIndent(cdc, account)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
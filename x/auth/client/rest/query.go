@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -19,6 +20,14 @@ func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, sto
 		"/accounts/{address}",
 		QueryAccountRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), ctx),
 	).Methods("GET")
+	r.HandleFunc(
+		"/accounts/number/{number}",
+		QueryAccountByNumberRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), ctx),
+	).Methods("GET")
+	r.HandleFunc(
+		"/accounts/pubkey/{pubkey}",
+		QueryAccountByPubKeyRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), ctx),
+	).Methods("GET")
 }
 
 // query accountREST Handler
@@ -66,3 +75,78 @@ func QueryAccountRequestHandlerFn(storeName string, cdc *wire.Codec, decoder aut
 		w.Write(output)
 	}
 }
+
+// query account by account number REST Handler
+func QueryAccountByNumberRequestHandlerFn(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		accNumber, err := strconv.ParseInt(vars["number"], 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		writeIndexedAccount(w, ctx, storeName, cdc, decoder, auth.AccountNumberStoreKey(accNumber))
+	}
+}
+
+// query account by public key REST Handler
+func QueryAccountByPubKeyRequestHandlerFn(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		pubKey, err := sdk.GetAccPubKeyBech32(vars["pubkey"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		writeIndexedAccount(w, ctx, storeName, cdc, decoder, auth.PubKeyStoreKey(pubKey))
+	}
+}
+
+// resolve an account address through a secondary index and write the account
+func writeIndexedAccount(w http.ResponseWriter, ctx context.CoreContext, storeName string, cdc *wire.Codec,
+	decoder auth.AccountDecoder, indexKey []byte) {
+
+	addr, err := ctx.QueryStore(indexKey, storeName)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("couldn't query account index. Error: %s", err.Error())))
+		return
+	}
+
+	// the query will return empty if no account is indexed under this key
+	if len(addr) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	res, err := ctx.QueryStore(auth.AddressStoreKey(addr), storeName)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("couldn't query account. Error: %s", err.Error())))
+		return
+	}
+	if len(res) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	account, err := decoder(res)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("couldn't parse query result. Result: %s. Error: %s", res, err.Error())))
+		return
+	}
+
+	output, err := cdc.MarshalJSON(account)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+		return
+	}
+
+	w.Write(output)
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all auth state that must be provided at genesis
+type GenesisState struct {
+	Params Params `json:"params"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, am AccountMapper, data GenesisState) {
+	am.SetParams(ctx, data.Params)
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, am AccountMapper) GenesisState {
+	return GenesisState{
+		Params: am.GetParams(ctx),
+	}
+}
@@ -1,11 +1,13 @@
 package auth
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 )
 
@@ -23,6 +25,9 @@ type AccountMapper struct {
 
 	// The wire codec for binary encoding/decoding of accounts.
 	cdc *wire.Codec
+
+	// The subspace of the ante handler params, may be unset
+	paramSpace params.Subspace
 }
 
 // NewAccountMapper returns a new sdk.AccountMapper that
@@ -67,6 +72,18 @@ func AddressStoreKey(addr sdk.Address) []byte {
 	return append([]byte("account:"), addr.Bytes()...)
 }
 
+// Turn an account number to the key of the index pointing to its address
+func AccountNumberStoreKey(accNumber int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(accNumber))
+	return append([]byte("accountNumber:"), bz...)
+}
+
+// Turn a public key to the key of the index pointing to its account address
+func PubKeyStoreKey(pubKey crypto.PubKey) []byte {
+	return append([]byte("pubKey:"), pubKey.Bytes()...)
+}
+
 // Implements sdk.AccountMapper.
 func (am AccountMapper) GetAccount(ctx sdk.Context, addr sdk.Address) Account {
 	store := ctx.KVStore(am.key)
@@ -84,6 +101,44 @@ func (am AccountMapper) SetAccount(ctx sdk.Context, acc Account) {
 	store := ctx.KVStore(am.key)
 	bz := am.encodeAccount(acc)
 	store.Set(AddressStoreKey(addr), bz)
+
+	// maintain the secondary indexes
+	store.Set(AccountNumberStoreKey(acc.GetAccountNumber()), addr)
+	if pubKey := acc.GetPubKey(); pubKey != nil {
+		store.Set(PubKeyStoreKey(pubKey), addr)
+	}
+}
+
+// Removes the account and its secondary indexes from the store.
+// The global account number is not reused, so an account later created
+// at the same address gets a new number and old signatures can't be replayed.
+func (am AccountMapper) RemoveAccount(ctx sdk.Context, acc Account) {
+	store := ctx.KVStore(am.key)
+	store.Delete(AddressStoreKey(acc.GetAddress()))
+	store.Delete(AccountNumberStoreKey(acc.GetAccountNumber()))
+	if pubKey := acc.GetPubKey(); pubKey != nil {
+		store.Delete(PubKeyStoreKey(pubKey))
+	}
+}
+
+// Returns the account with the given account number
+func (am AccountMapper) GetAccountByNumber(ctx sdk.Context, accNumber int64) Account {
+	store := ctx.KVStore(am.key)
+	addr := store.Get(AccountNumberStoreKey(accNumber))
+	if addr == nil {
+		return nil
+	}
+	return am.GetAccount(ctx, addr)
+}
+
+// Returns the account which has set the given public key
+func (am AccountMapper) GetAccountByPubKey(ctx sdk.Context, pubKey crypto.PubKey) Account {
+	store := ctx.KVStore(am.key)
+	addr := store.Get(PubKeyStoreKey(pubKey))
+	if addr == nil {
+		return nil
+	}
+	return am.GetAccount(ctx, addr)
 }
 
 // Implements sdk.AccountMapper.
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/log"
 
@@ -60,3 +61,72 @@ func TestAccountMapperGetSet(t *testing.T) {
 	require.NotNil(t, acc)
 	require.Equal(t, newSequence, acc.GetSequence())
 }
+
+func TestAccountMapperSecondaryIndexes(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+
+	pubKey := crypto.GenPrivKeyEd25519().PubKey()
+	addr := sdk.Address(pubKey.Address())
+
+	// nothing is indexed before the account is set
+	require.Nil(t, mapper.GetAccountByNumber(ctx, 0))
+	require.Nil(t, mapper.GetAccountByPubKey(ctx, pubKey))
+
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	mapper.SetAccount(ctx, acc)
+
+	// the account number is indexed, the pubkey is not set yet
+	acc = mapper.GetAccountByNumber(ctx, acc.GetAccountNumber())
+	require.NotNil(t, acc)
+	require.Equal(t, addr, acc.GetAddress())
+	require.Nil(t, mapper.GetAccountByPubKey(ctx, pubKey))
+
+	acc.SetPubKey(pubKey)
+	mapper.SetAccount(ctx, acc)
+
+	acc = mapper.GetAccountByPubKey(ctx, pubKey)
+	require.NotNil(t, acc)
+	require.Equal(t, addr, acc.GetAddress())
+	require.Nil(t, mapper.GetAccountByNumber(ctx, acc.GetAccountNumber()+1))
+
+	// the indexes don't show up as accounts
+	count := 0
+	mapper.IterateAccounts(ctx, func(Account) bool {
+		count++
+		return false
+	})
+	require.Equal(t, 1, count)
+}
+
+func TestAccountMapperModuleAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+
+	// coins sent to the module address before the module account exists
+	addr := NewModuleAddress("escrow")
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(sdk.Coins{sdk.NewCoin("foocoin", 5)})
+	mapper.SetAccount(ctx, acc)
+
+	// are kept when the module account is created
+	macc := mapper.GetModuleAccount(ctx, "escrow", Burner)
+	require.Equal(t, addr, macc.GetAddress())
+	require.Equal(t, acc.GetAccountNumber(), macc.GetAccountNumber())
+	require.True(t, macc.GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 5)}))
+	require.True(t, macc.HasPermission(Burner))
+
+	// the stored module account is returned afterwards
+	macc = mapper.GetModuleAccount(ctx, "escrow")
+	require.Equal(t, "escrow", macc.Name)
+	require.True(t, macc.HasPermission(Burner))
+	require.NotNil(t, macc.SetPubKey(crypto.GenPrivKeyEd25519().PubKey()))
+}
@@ -43,7 +43,7 @@ func GenTx(msgs []sdk.Msg, accnums []int64, seq []int64, priv ...crypto.PrivKeyE
 	sigs := make([]auth.StdSignature, len(priv))
 	memo := "testmemotestmemo"
 	for i, p := range priv {
-		sig, err := p.Sign(auth.StdSignBytes(chainID, accnums[i], seq[i], fee, msgs, memo))
+		sig, err := p.Sign(auth.StdSignBytes(chainID, accnums[i], seq[i], fee, msgs, memo, 0, false))
 		if err != nil {
 			panic(err)
 		}
@@ -54,7 +54,7 @@ func GenTx(msgs []sdk.Msg, accnums []int64, seq []int64, priv ...crypto.PrivKeyE
 			Sequence:      seq[i],
 		}
 	}
-	return auth.NewStdTx(msgs, fee, sigs, memo)
+	return auth.NewStdTx(msgs, fee, sigs, memo, 0, false)
 }
 
 // generate a set of signed transactions a msg, that differ only by having the
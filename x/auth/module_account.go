@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// permissions a module account can be declared with
+const (
+	Minter  = "minter"  // can create new coins
+	Burner  = "burner"  // can destroy the coins it holds
+	Staking = "staking" // can escrow delegated coins
+)
+
+// FeeCollectorName is the name of the module account holding the collected fees
+const FeeCollectorName = "fee_collector"
+
+var _ Account = (*ModuleAccount)(nil)
+
+// ModuleAccount - account owned by a module rather than by a key pair,
+// holding the coins the module escrows. Its address is derived from its name
+// and it can never sign transactions.
+type ModuleAccount struct {
+	BaseAccount
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// NewModuleAddress returns the address of the module account of the given name
+func NewModuleAddress(name string) sdk.Address {
+	return sdk.Address(crypto.Sha256([]byte(name))[:20])
+}
+
+func NewModuleAccount(name string, permissions ...string) *ModuleAccount {
+	return &ModuleAccount{
+		BaseAccount: NewBaseAccountWithAddress(NewModuleAddress(name)),
+		Name:        name,
+		Permissions: permissions,
+	}
+}
+
+// HasPermission returns whether the module account was declared with the permission
+func (acc ModuleAccount) HasPermission(permission string) bool {
+	for _, perm := range acc.Permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Implements sdk.Account, module accounts have no pubkey.
+func (acc *ModuleAccount) SetPubKey(pubKey crypto.PubKey) error {
+	return errors.New("cannot set the pubkey of a module account")
+}
+
+// GetModuleAccount returns the module account of the given name, creating it
+// with the given permissions if it doesn't exist yet. A plain account found at
+// the module address (eg. after coins were sent to it) is converted, keeping
+// its coins and account number.
+func (am AccountMapper) GetModuleAccount(ctx sdk.Context, name string, permissions ...string) *ModuleAccount {
+	macc := NewModuleAccount(name, permissions...)
+	acc := am.GetAccount(ctx, macc.Address)
+	if acc == nil {
+		macc.AccountNumber = am.GetNextAccountNumber(ctx)
+		am.SetAccount(ctx, macc)
+		return macc
+	}
+	if existing, ok := acc.(*ModuleAccount); ok {
+		return existing
+	}
+
+	macc.AccountNumber = acc.GetAccountNumber()
+	macc.Coins = acc.GetCoins()
+	am.SetAccount(ctx, macc)
+	return macc
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// default paramspace for the auth params
+const DefaultParamspace = "auth"
+
+// keys of the params in the paramspace
+var (
+	KeyMaxMemoCharacters        = []byte("MaxMemoCharacters")
+	KeyMemoCostPerByte          = []byte("MemoCostPerByte")
+	KeySigVerifyCost            = []byte("SigVerifyCost")
+	KeyMaxUnorderedTimeoutDelta = []byte("MaxUnorderedTimeoutDelta")
+)
+
+// Params - used by the ante handler, settable by governance
+type Params struct {
+	MaxMemoCharacters int64   `json:"max_memo_characters"`
+	MemoCostPerByte   sdk.Gas `json:"memo_cost_per_byte"`
+	SigVerifyCost     sdk.Gas `json:"sig_verify_cost"`
+
+	// maximum number of blocks ahead of the current height an unordered tx
+	// may time out, it bounds how long the hash of every unordered tx is kept
+	MaxUnorderedTimeoutDelta int64 `json:"max_unordered_timeout_delta"`
+}
+
+// DefaultParams returns the default auth params
+func DefaultParams() Params {
+	return Params{
+		MaxMemoCharacters:        100,
+		MemoCostPerByte:          1,
+		SigVerifyCost:            100,
+		MaxUnorderedTimeoutDelta: 500,
+	}
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyMaxMemoCharacters, &p.MaxMemoCharacters, validatePositive},
+		{KeyMemoCostPerByte, &p.MemoCostPerByte, validateGas},
+		{KeySigVerifyCost, &p.SigVerifyCost, validateGas},
+		{KeyMaxUnorderedTimeoutDelta, &p.MaxUnorderedTimeoutDelta, validatePositive},
+	}
+}
+
+// ParamKeyTable declares the auth params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func validatePositive(value interface{}) error {
+	if value.(int64) <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func validateGas(value interface{}) error {
+	if value.(sdk.Gas) < 0 {
+		return errors.New("cannot be negative")
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// WithParamSpace returns a copy of the mapper reading the params of the ante
+// handler from the subspace, without it the default params are used
+func (am AccountMapper) WithParamSpace(paramSpace params.Subspace) AccountMapper {
+	am.paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
+	return am
+}
+
+// GetParams returns the auth params
+func (am AccountMapper) GetParams(ctx sdk.Context) (params Params) {
+	if !am.paramSpace.HasKeyTable() {
+		return DefaultParams()
+	}
+	am.paramSpace.GetParamSet(ctx, &params)
+	return
+}
+
+// SetParams sets the auth params
+func (am AccountMapper) SetParams(ctx sdk.Context, params Params) {
+	am.paramSpace.SetParamSet(ctx, &params)
+}
@@ -11,19 +11,26 @@ var _ sdk.Tx = (*StdTx)(nil)
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
 // NOTE: the first signature is the FeePayer (Signatures must not be nil).
+// A non-zero TimeoutHeight is the last block height the tx can be included in.
+// Unordered txs skip the sequence check and are instead deduplicated by hash
+// until their (mandatory) timeout height.
 type StdTx struct {
-	Msgs       []sdk.Msg      `json:"msg"`
-	Fee        StdFee         `json:"fee"`
-	Signatures []StdSignature `json:"signatures"`
-	Memo       string         `json:"memo"`
+	Msgs          []sdk.Msg      `json:"msg"`
+	Fee           StdFee         `json:"fee"`
+	Signatures    []StdSignature `json:"signatures"`
+	Memo          string         `json:"memo"`
+	TimeoutHeight int64          `json:"timeout_height"`
+	Unordered     bool           `json:"unordered"`
 }
 
-func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string) StdTx {
+func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string, timeoutHeight int64, unordered bool) StdTx {
 	return StdTx{
-		Msgs:       msgs,
-		Fee:        fee,
-		Signatures: sigs,
-		Memo:       memo,
+		Msgs:          msgs,
+		Fee:           fee,
+		Signatures:    sigs,
+		Memo:          memo,
+		TimeoutHeight: timeoutHeight,
+		Unordered:     unordered,
 	}
 }
 
@@ -50,7 +57,9 @@ func (tx StdTx) GetSigners() []sdk.Address {
 }
 
 //nolint
-func (tx StdTx) GetMemo() string { return tx.Memo }
+func (tx StdTx) GetMemo() string         { return tx.Memo }
+func (tx StdTx) GetTimeoutHeight() int64 { return tx.TimeoutHeight }
+func (tx StdTx) IsUnordered() bool       { return tx.Unordered }
 
 // Signatures returns the signature of signers who signed the Msg.
 // GetSignatures returns the signature of signers who signed the Msg.
@@ -109,6 +118,8 @@ func (fee StdFee) Bytes() []byte {
 // as well as the ChainID (prevent cross chain replay)
 // and the Sequence numbers for each signature (prevent
 // inchain replay and enforce tx ordering per account).
+// The TimeoutHeight and Unordered flag are omitted when unset so the
+// sign bytes of txs not using them are unchanged.
 type StdSignDoc struct {
 	ChainID       string          `json:"chain_id"`
 	AccountNumber int64           `json:"account_number"`
@@ -116,11 +127,13 @@ type StdSignDoc struct {
 	FeeBytes      json.RawMessage `json:"fee_bytes"`
 	MsgsBytes     json.RawMessage `json:"msg_bytes"`
 	Memo          string          `json:"memo"`
+	TimeoutHeight int64           `json:"timeout_height,omitempty"`
+	Unordered     bool            `json:"unordered,omitempty"`
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
 // TODO: change the API to just take a chainID and StdTx ?
-func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs []sdk.Msg, memo string) []byte {
+func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs []sdk.Msg, memo string, timeoutHeight int64, unordered bool) []byte {
 	var msgsBytes []json.RawMessage
 	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
@@ -137,6 +150,8 @@ func StdSignBytes(chainID string, accnum int64, sequence int64, fee StdFee, msgs
 		FeeBytes:      json.RawMessage(fee.Bytes()),
 		MsgsBytes:     json.RawMessage(msgBytes),
 		Memo:          memo,
+		TimeoutHeight: timeoutHeight,
+		Unordered:     unordered,
 	})
 	if err != nil {
 		panic(err)
@@ -154,11 +169,13 @@ type StdSignMsg struct {
 	Fee           StdFee
 	Msgs          []sdk.Msg
 	Memo          string
+	TimeoutHeight int64
+	Unordered     bool
 }
 
 // get message bytes
 func (msg StdSignMsg) Bytes() []byte {
-	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo, msg.TimeoutHeight, msg.Unordered)
 }
 
 // Standard Signature
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -23,10 +24,46 @@ func TestStdTx(t *testing.T) {
 	fee := newStdFee()
 	sigs := []StdSignature{}
 
-	tx := NewStdTx(msgs, fee, sigs, "")
+	tx := NewStdTx(msgs, fee, sigs, "", 0, false)
 	require.Equal(t, msgs, tx.GetMsgs())
 	require.Equal(t, sigs, tx.GetSignatures())
 
 	feePayer := FeePayer(tx)
 	require.Equal(t, addr, feePayer)
 }
+
+func TestStdSignBytesTimeoutHeight(t *testing.T) {
+	priv := crypto.GenPrivKeyEd25519()
+	addr := priv.PubKey().Address()
+	msgs := []sdk.Msg{sdk.NewTestMsg(addr)}
+	fee := newStdFee()
+
+	// the sign doc as it was before the timeout height was introduced
+	type legacyStdSignDoc struct {
+		ChainID       string          `json:"chain_id"`
+		AccountNumber int64           `json:"account_number"`
+		Sequence      int64           `json:"sequence"`
+		FeeBytes      json.RawMessage `json:"fee_bytes"`
+		MsgsBytes     json.RawMessage `json:"msg_bytes"`
+		Memo          string          `json:"memo"`
+	}
+	msgBytes, err := msgCdc.MarshalJSON([]json.RawMessage{json.RawMessage(msgs[0].GetSignBytes())})
+	require.Nil(t, err)
+	legacy, err := msgCdc.MarshalJSON(legacyStdSignDoc{
+		ChainID:       "mychainid",
+		AccountNumber: 1,
+		Sequence:      2,
+		FeeBytes:      json.RawMessage(fee.Bytes()),
+		MsgsBytes:     json.RawMessage(msgBytes),
+		Memo:          "memo",
+	})
+	require.Nil(t, err)
+
+	// txs without a timeout keep the same sign bytes
+	require.Equal(t, legacy, StdSignBytes("mychainid", 1, 2, fee, msgs, "memo", 0, false))
+
+	// txs with a timeout sign over it
+	signBytes := StdSignBytes("mychainid", 1, 2, fee, msgs, "memo", 5, false)
+	require.NotEqual(t, legacy, signBytes)
+	require.Contains(t, string(signBytes), "timeout_height")
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	unorderedTxKey        = []byte("unorderedTx:")        // prefix for the hashes of seen unordered txs
+	unorderedTxTimeoutKey = []byte("unorderedTxTimeout:") // prefix for the same hashes indexed by timeout height
+)
+
+// Turn the hash of an unordered tx to the key used to deduplicate it
+func UnorderedTxStoreKey(txHash []byte) []byte {
+	return append(unorderedTxKey, txHash...)
+}
+
+// Turn a timeout height and tx hash to the key of the pruning index
+func unorderedTxTimeoutStoreKey(timeoutHeight int64, txHash []byte) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(timeoutHeight))
+	return append(append(unorderedTxTimeoutKey, bz...), txHash...)
+}
+
+// hash identifying an unordered tx, taken over the fee payer's sign bytes
+// so that it doesn't depend on the tx encoding
+func unorderedTxHash(signBytes []byte) []byte {
+	hash := sha256.Sum256(signBytes)
+	return hash[:]
+}
+
+// Returns true if an unordered tx with this hash has been seen and not pruned yet
+func (am AccountMapper) HasUnorderedTx(ctx sdk.Context, txHash []byte) bool {
+	store := ctx.KVStore(am.key)
+	return store.Has(UnorderedTxStoreKey(txHash))
+}
+
+// record an unordered tx until its timeout height
+func (am AccountMapper) setUnorderedTx(ctx sdk.Context, txHash []byte, timeoutHeight int64) {
+	store := ctx.KVStore(am.key)
+	store.Set(UnorderedTxStoreKey(txHash), am.cdc.MustMarshalBinary(timeoutHeight))
+	store.Set(unorderedTxTimeoutStoreKey(timeoutHeight, txHash), txHash)
+}
+
+// PruneUnorderedTxs removes the hashes of the unordered txs which have timed
+// out by the current block height, to be called at the end of every block.
+func (am AccountMapper) PruneUnorderedTxs(ctx sdk.Context) {
+	store := ctx.KVStore(am.key)
+	end := unorderedTxTimeoutStoreKey(ctx.BlockHeight()+1, nil)
+	iterator := store.Iterator(unorderedTxTimeoutKey, end)
+
+	var keys, hashes [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		hashes = append(hashes, iterator.Value())
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		store.Delete(key)
+		store.Delete(UnorderedTxStoreKey(hashes[i]))
+	}
+}
@@ -0,0 +1,170 @@
+package authz
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// MsgName returns the name of the concrete type of a msg, its route followed
+// by its Go type name, eg. "stake/MsgBeginRedelegate". Grants are keyed on it
+// so that an authorization never applies to the other msgs of the same route.
+func MsgName(msg sdk.Msg) string {
+	return msg.Type() + "/" + reflect.Indirect(reflect.ValueOf(msg)).Type().Name()
+}
+
+// names of the msgs which may also be executed with the grant of another msg,
+// a weighted vote being allowed by the authorization to vote
+var grantedWith = map[string]string{
+	MsgName(gov.MsgVoteWeighted{}): MsgName(gov.MsgVote{}),
+}
+
+// route of the msgs with the given name
+func msgNameRoute(msgName string) string {
+	return strings.SplitN(msgName, "/", 2)[0]
+}
+
+// Authorization represents the permission a granter gives a grantee to
+// execute messages of a given type on its behalf
+type Authorization interface {
+	// MsgType is the name of the msgs this authorization applies to, see MsgName
+	MsgType() string
+
+	// Accept determines whether the grantee may execute msg. If the authorization
+	// is limited, the updated authorization is returned and should be stored in
+	// place of the previous one. If del is true the authorization is exhausted
+	// and should be deleted.
+	Accept(msg sdk.Msg, blockTime int64) (allow bool, updated Authorization, del bool)
+
+	// ValidateBasic checks the authorization before it is granted
+	ValidateBasic() sdk.Error
+}
+
+// Grant pairs an authorization with the block time after which it expires.
+// An Expiration of zero never expires.
+type Grant struct {
+	Authorization Authorization `json:"authorization"`
+	Expiration    int64         `json:"expiration"`
+}
+
+// NewGrant creates a new Grant
+func NewGrant(authorization Authorization, expiration int64) Grant {
+	return Grant{
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// whether the grant has expired at the given block time
+func (g Grant) expired(blockTime int64) bool {
+	return g.Expiration != 0 && blockTime > g.Expiration
+}
+
+//-----------------------------------------------------------
+// GenericAuthorization
+
+var _ Authorization = GenericAuthorization{}
+
+// GenericAuthorization gives the grantee unrestricted permission to execute
+// any message with the given name (see MsgName) on behalf of the granter
+type GenericAuthorization struct {
+	Type string `json:"type"`
+}
+
+// nolint
+func (ga GenericAuthorization) MsgType() string { return ga.Type }
+func (ga GenericAuthorization) Accept(msg sdk.Msg, blockTime int64) (bool, Authorization, bool) {
+	return MsgName(msg) == ga.Type, ga, false
+}
+func (ga GenericAuthorization) ValidateBasic() sdk.Error {
+	if len(ga.Type) == 0 {
+		return ErrInvalidGrant(DefaultCodespace, "msg type cannot be empty")
+	}
+	if !strings.Contains(ga.Type, "/") {
+		return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("msg type %q is not a msg name, eg. \"stake/MsgBeginRedelegate\"", ga.Type))
+	}
+	return nil
+}
+
+//-----------------------------------------------------------
+// SendAuthorization
+
+var _ Authorization = SendAuthorization{}
+
+// SendAuthorization allows the grantee to send up to SpendLimit coins
+// from the granter's account
+type SendAuthorization struct {
+	SpendLimit sdk.Coins `json:"spend_limit"`
+}
+
+// nolint
+func (sa SendAuthorization) MsgType() string { return MsgName(bank.MsgSend{}) }
+
+// Implements Authorization. The spend limit must be valid and positive.
+func (sa SendAuthorization) ValidateBasic() sdk.Error {
+	if !sa.SpendLimit.IsValid() || !sa.SpendLimit.IsPositive() {
+		return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("invalid spend limit %v", sa.SpendLimit))
+	}
+	return nil
+}
+
+// Implements Authorization. Only MsgSends with the granter as the sole input
+// are accepted, and the spend limit is reduced by the amount sent.
+func (sa SendAuthorization) Accept(msg sdk.Msg, blockTime int64) (bool, Authorization, bool) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok || len(send.Inputs) != 1 {
+		return false, sa, false
+	}
+	limitLeft := sa.SpendLimit.Minus(send.Inputs[0].Coins)
+	if !limitLeft.IsNotNegative() {
+		return false, sa, false
+	}
+	if limitLeft.IsZero() {
+		return true, nil, true
+	}
+	return true, SendAuthorization{SpendLimit: limitLeft}, false
+}
+
+//-----------------------------------------------------------
+// VoteAuthorization
+
+var _ Authorization = VoteAuthorization{}
+
+// VoteAuthorization allows the grantee to vote on governance proposals
+// on behalf of the granter, splitting the vote or not, but not to submit
+// proposals or deposit
+type VoteAuthorization struct{}
+
+// nolint
+func (va VoteAuthorization) MsgType() string { return MsgName(gov.MsgVote{}) }
+func (va VoteAuthorization) Accept(msg sdk.Msg, blockTime int64) (bool, Authorization, bool) {
+	switch msg.(type) {
+	case gov.MsgVote, gov.MsgVoteWeighted:
+		return true, va, false
+	default:
+		return false, va, false
+	}
+}
+func (va VoteAuthorization) ValidateBasic() sdk.Error { return nil }
+
+//-----------------------------------------------------------
+// RedelegateAuthorization
+
+var _ Authorization = RedelegateAuthorization{}
+
+// RedelegateAuthorization allows the grantee to move the delegations of the
+// granter between validators, but not to delegate, unbond or edit a validator
+type RedelegateAuthorization struct{}
+
+// nolint
+func (ra RedelegateAuthorization) MsgType() string { return MsgName(stake.MsgBeginRedelegate{}) }
+func (ra RedelegateAuthorization) Accept(msg sdk.Msg, blockTime int64) (bool, Authorization, bool) {
+	_, ok := msg.(stake.MsgBeginRedelegate)
+	return ok, ra, false
+}
+func (ra RedelegateAuthorization) ValidateBasic() sdk.Error { return nil }
@@ -0,0 +1,38 @@
+//nolint
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeNoAuthorization   sdk.CodeType = 1
+	CodeAuthorizationDeny sdk.CodeType = 2
+	CodeInvalidExecMsg    sdk.CodeType = 3
+	CodeInvalidGrant      sdk.CodeType = 4
+)
+
+//----------------------------------------
+// Error constructors
+
+func ErrNoAuthorization(codespace sdk.CodespaceType, granter, grantee sdk.Address, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAuthorization,
+		fmt.Sprintf("%v has not authorized %v to execute %s messages", granter, grantee, msgType))
+}
+
+func ErrAuthorizationDeny(codespace sdk.CodespaceType, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationDeny,
+		fmt.Sprintf("%s message is not permitted by the authorization", msgType))
+}
+
+func ErrInvalidExecMsg(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidExecMsg, msg)
+}
+
+func ErrInvalidGrant(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGrant, msg)
+}
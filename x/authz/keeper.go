@@ -0,0 +1,141 @@
+package authz
+
+import (
+	"bytes"
+	"fmt"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the authz store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// router used to dispatch the messages executed on behalf of a granter
+	router bam.Router
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an authz keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, router bam.Router, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		router:    router,
+		codespace: codespace,
+	}
+}
+
+// GetGrant returns the grant of a msg type given by a granter to a grantee
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.Address, msgType string) (grant Grant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetGrantKey(granter, grantee, msgType))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant, true
+}
+
+// Grant stores the authorization given by a granter to a grantee, replacing
+// any existing authorization for the same msg type
+func (k Keeper) Grant(ctx sdk.Context, granter, grantee sdk.Address, grant Grant) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(grant)
+	store.Set(GetGrantKey(granter, grantee, grant.Authorization.MsgType()), bz)
+}
+
+// Revoke removes the authorization of a msg type given by a granter to a grantee
+func (k Keeper) Revoke(ctx sdk.Context, granter, grantee sdk.Address, msgType string) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetGrantKey(granter, grantee, msgType)
+	if !store.Has(key) {
+		return ErrNoAuthorization(k.codespace, granter, grantee, msgType)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetGrants returns all the grants given by a granter to a grantee
+func (k Keeper) GetGrants(ctx sdk.Context, granter, grantee sdk.Address) (grants []Grant) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetGrantsKey(granter, grantee))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// DispatchActions executes msgs on behalf of their signers. Each msg must have
+// a single signer which is either the grantee itself or a granter which has
+// given the grantee an authorization accepting the msg.
+func (k Keeper) DispatchActions(ctx sdk.Context, grantee sdk.Address, msgs []sdk.Msg) sdk.Result {
+	res := sdk.Result{}
+	for _, msg := range msgs {
+		signers := msg.GetSigners()
+		if len(signers) != 1 {
+			return ErrInvalidExecMsg(k.codespace, "executed msgs must have exactly one signer").Result()
+		}
+
+		granter := signers[0]
+		if !bytes.Equal(granter, grantee) {
+			err := k.useAuthorization(ctx, granter, grantee, msg)
+			if err != nil {
+				return err.Result()
+			}
+		}
+
+		handler := k.router.Route(msg.Type())
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msg.Type()).Result()
+		}
+
+		msgResult := handler(ctx, msg)
+		if !msgResult.IsOK() {
+			return msgResult
+		}
+		res.Data = append(res.Data, msgResult.Data...)
+		res.Tags = res.Tags.AppendTags(msgResult.Tags)
+	}
+	return res
+}
+
+// check the authorization for msg and store its updated state
+func (k Keeper) useAuthorization(ctx sdk.Context, granter, grantee sdk.Address, msg sdk.Msg) sdk.Error {
+	blockTime := ctx.BlockHeader().Time
+	msgName := MsgName(msg)
+	grantName := msgName
+	grant, found := k.GetGrant(ctx, granter, grantee, grantName)
+	if other, ok := grantedWith[msgName]; !found && ok {
+		grantName = other
+		grant, found = k.GetGrant(ctx, granter, grantee, grantName)
+	}
+	if !found || grant.expired(blockTime) {
+		return ErrNoAuthorization(k.codespace, granter, grantee, msgName)
+	}
+
+	allow, updated, del := grant.Authorization.Accept(msg, blockTime)
+	if !allow {
+		return ErrAuthorizationDeny(k.codespace, msgName)
+	}
+
+	if del {
+		err := k.Revoke(ctx, granter, grantee, grantName)
+		if err != nil {
+			panic(fmt.Sprintf("authorization should exist: %v", err))
+		}
+		return nil
+	}
+	grant.Authorization = updated
+	k.Grant(ctx, granter, grantee, grant)
+	return nil
+}
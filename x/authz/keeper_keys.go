@@ -0,0 +1,26 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+var (
+	GrantKey = []byte{0x01} // prefix for each key to a grant
+)
+
+// Key for getting the grant of a specific msg type given by a granter to a grantee
+func GetGrantKey(granter, grantee sdk.Address, msgType string) []byte {
+	return append(GetGrantsKey(granter, grantee), []byte(msgType)...)
+}
+
+// Key for getting all grants given by a granter to a grantee, the addresses
+// are length prefixed so that the keys of different pairs can't collide
+func GetGrantsKey(granter, grantee sdk.Address) []byte {
+	key := make([]byte, 0, len(GrantKey)+2+len(granter)+len(grantee))
+	key = append(key, GrantKey...)
+	key = append(key, byte(len(granter)))
+	key = append(key, granter.Bytes()...)
+	key = append(key, byte(len(grantee)))
+	return append(key, grantee.Bytes()...)
+}
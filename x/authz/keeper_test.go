@@ -0,0 +1,190 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	granter = sdk.Address([]byte("granter"))
+	grantee = sdk.Address([]byte("grantee"))
+	other   = sdk.Address([]byte("other"))
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, bank.Keeper) {
+	db := dbm.NewMemDB()
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
+	keyAuthz := sdk.NewKVStoreKey("authz")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAuthz, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	RegisterWire(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: 10}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
+	ck := bank.NewKeeper(cdc, keyBank, accountMapper)
+
+	router := bam.NewRouter()
+	router.AddRoute("bank", bank.NewHandler(ck))
+	keeper := NewKeeper(cdc, keyAuthz, router, DefaultCodespace)
+
+	return ctx, keeper, ck
+}
+
+var sendMsgName = MsgName(bank.MsgSend{})
+
+func newSendMsg(from, to sdk.Address, coins sdk.Coins) bank.MsgSend {
+	return bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+}
+
+func TestGrantRevoke(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+
+	_, found := keeper.GetGrant(ctx, granter, grantee, sendMsgName)
+	require.False(t, found)
+
+	keeper.Grant(ctx, granter, grantee, NewGrant(GenericAuthorization{sendMsgName}, 0))
+	grant, found := keeper.GetGrant(ctx, granter, grantee, sendMsgName)
+	require.True(t, found)
+	require.Equal(t, GenericAuthorization{sendMsgName}, grant.Authorization)
+	require.Len(t, keeper.GetGrants(ctx, granter, grantee), 1)
+
+	// grants are directional
+	_, found = keeper.GetGrant(ctx, grantee, granter, sendMsgName)
+	require.False(t, found)
+
+	require.Nil(t, keeper.Revoke(ctx, granter, grantee, sendMsgName))
+	_, found = keeper.GetGrant(ctx, granter, grantee, sendMsgName)
+	require.False(t, found)
+	require.NotNil(t, keeper.Revoke(ctx, granter, grantee, sendMsgName))
+}
+
+func TestDispatchActions(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	ck.MintCoins(ctx, granter, sdk.Coins{sdk.NewCoin("steak", 100)})
+
+	msg := newSendMsg(granter, other, sdk.Coins{sdk.NewCoin("steak", 30)})
+
+	// no authorization
+	res := keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	// limited send authorization
+	keeper.Grant(ctx, granter, grantee, NewGrant(SendAuthorization{sdk.Coins{sdk.NewCoin("steak", 50)}}, 0))
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(70), ck.GetCoins(ctx, granter).AmountOf("steak").Int64())
+	require.Equal(t, int64(30), ck.GetCoins(ctx, other).AmountOf("steak").Int64())
+
+	grant, found := keeper.GetGrant(ctx, granter, grantee, sendMsgName)
+	require.True(t, found)
+	require.Equal(t, SendAuthorization{sdk.Coins{sdk.NewCoin("steak", 20)}}, grant.Authorization)
+
+	// exceeds the remaining spend limit
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.False(t, res.IsOK())
+
+	// spending the exact remainder exhausts the authorization
+	msg = newSendMsg(granter, other, sdk.Coins{sdk.NewCoin("steak", 20)})
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK(), res.Log)
+	_, found = keeper.GetGrant(ctx, granter, grantee, sendMsgName)
+	require.False(t, found)
+
+	// the grantee can always act for itself
+	ck.MintCoins(ctx, grantee, sdk.Coins{sdk.NewCoin("steak", 10)})
+	msg = newSendMsg(grantee, other, sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestDispatchActionsExpired(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	ck.MintCoins(ctx, granter, sdk.Coins{sdk.NewCoin("steak", 100)})
+	msg := newSendMsg(granter, other, sdk.Coins{sdk.NewCoin("steak", 30)})
+
+	keeper.Grant(ctx, granter, grantee, NewGrant(GenericAuthorization{sendMsgName}, 5))
+	res := keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.False(t, res.IsOK())
+
+	keeper.Grant(ctx, granter, grantee, NewGrant(GenericAuthorization{sendMsgName}, 10))
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestDispatchActionsMsgName(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	ck.MintCoins(ctx, granter, sdk.Coins{sdk.NewCoin("steak", 100)})
+	msg := newSendMsg(granter, other, sdk.Coins{sdk.NewCoin("steak", 30)})
+
+	// grants of other msgs of the same route neither apply nor replace each other
+	keeper.Grant(ctx, granter, grantee, NewGrant(GenericAuthorization{MsgName(bank.MsgIssue{})}, 0))
+	res := keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	keeper.Grant(ctx, granter, grantee, NewGrant(SendAuthorization{sdk.Coins{sdk.NewCoin("steak", 50)}}, 0))
+	require.Len(t, keeper.GetGrants(ctx, granter, grantee), 2)
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK(), res.Log)
+
+	// a redelegate authorization does not allow unbonding
+	keeper.Grant(ctx, granter, grantee, NewGrant(RedelegateAuthorization{}, 0))
+	unbond := stake.NewMsgBeginUnbonding(granter, other, sdk.NewRat(10))
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{unbond})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	redelegate := stake.NewMsgBeginRedelegate(granter, other, grantee, sdk.NewRat(10))
+	allow, _, _ := RedelegateAuthorization{}.Accept(redelegate, 0)
+	require.True(t, allow)
+	allow, _, _ = RedelegateAuthorization{}.Accept(unbond, 0)
+	require.False(t, allow)
+}
+
+func TestDispatchActionsVote(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	var voted []sdk.Msg
+	keeper.router.AddRoute("gov", func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		voted = append(voted, msg)
+		return sdk.Result{}
+	})
+
+	vote := gov.NewMsgVote(granter, 1, gov.OptionYes)
+	weighted := gov.NewMsgVoteWeighted(granter, 1, []gov.WeightedVoteOption{
+		{Option: gov.OptionYes, Weight: sdk.NewRat(1, 2)},
+		{Option: gov.OptionNo, Weight: sdk.NewRat(1, 2)},
+	})
+	res := keeper.DispatchActions(ctx, grantee, []sdk.Msg{weighted})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	// the authorization to vote allows split votes too
+	keeper.Grant(ctx, granter, grantee, NewGrant(VoteAuthorization{}, 0))
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{vote, weighted})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []sdk.Msg{vote, weighted}, voted)
+
+	// but not deposits
+	deposit := gov.NewMsgDeposit(granter, 1, sdk.Coins{sdk.NewCoin("steak", 10)})
+	res = keeper.DispatchActions(ctx, grantee, []sdk.Msg{deposit})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+	require.Len(t, voted, 2)
+}
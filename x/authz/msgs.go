@@ -0,0 +1,207 @@
+package authz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "authz"
+
+//-----------------------------------------------------------
+// MsgGrant
+
+// MsgGrant - grants the grantee an authorization to execute msgs on behalf of the granter
+type MsgGrant struct {
+	Granter       sdk.Address   `json:"granter"`
+	Grantee       sdk.Address   `json:"grantee"`
+	Authorization Authorization `json:"authorization"`
+	Expiration    int64         `json:"expiration"` // block time after which the grant is void, 0 for never
+}
+
+var _ sdk.Msg = MsgGrant{}
+
+func NewMsgGrant(granter, grantee sdk.Address, authorization Authorization, expiration int64) MsgGrant {
+	return MsgGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+//nolint
+func (msg MsgGrant) Type() string              { return MsgType }
+func (msg MsgGrant) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// Implements Msg.
+func (msg MsgGrant) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if bytes.Equal(msg.Granter, msg.Grantee) {
+		return ErrInvalidGrant(DefaultCodespace, "granter and grantee cannot be the same")
+	}
+	if msg.Authorization == nil {
+		return ErrInvalidGrant(DefaultCodespace, "authorization cannot be empty")
+	}
+	if err := msg.Authorization.ValidateBasic(); err != nil {
+		return err
+	}
+	// a grantee allowed to execute authz msgs could grant itself anything
+	if msgNameRoute(msg.Authorization.MsgType()) == MsgType {
+		return ErrInvalidGrant(DefaultCodespace, "authz msgs cannot be authorized")
+	}
+	if msg.Expiration < 0 {
+		return ErrInvalidGrant(DefaultCodespace, "expiration cannot be negative")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgGrant) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter       string        `json:"granter"`
+		Grantee       string        `json:"grantee"`
+		Authorization Authorization `json:"authorization"`
+		Expiration    int64         `json:"expiration"`
+	}{
+		Granter:       sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee:       sdk.MustBech32ifyAcc(msg.Grantee),
+		Authorization: msg.Authorization,
+		Expiration:    msg.Expiration,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgGrant) String() string {
+	return fmt.Sprintf("MsgGrant{%v -> %v: %v}", msg.Granter, msg.Grantee, msg.Authorization.MsgType())
+}
+
+//-----------------------------------------------------------
+// MsgRevoke
+
+// MsgRevoke - revokes the authorization of a msg type given to the grantee,
+// the msg type is the name of the msgs authorized (see MsgName)
+type MsgRevoke struct {
+	Granter sdk.Address `json:"granter"`
+	Grantee sdk.Address `json:"grantee"`
+	MsgType string      `json:"msg_type"`
+}
+
+var _ sdk.Msg = MsgRevoke{}
+
+func NewMsgRevoke(granter, grantee sdk.Address, msgType string) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+//nolint
+func (msg MsgRevoke) Type() string              { return MsgType }
+func (msg MsgRevoke) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// Implements Msg.
+func (msg MsgRevoke) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if len(msg.MsgType) == 0 {
+		return ErrInvalidGrant(DefaultCodespace, "msg type cannot be empty")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRevoke) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter string `json:"granter"`
+		Grantee string `json:"grantee"`
+		MsgType string `json:"msg_type"`
+	}{
+		Granter: sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		MsgType: msg.MsgType,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+//-----------------------------------------------------------
+// MsgExec
+
+// MsgExec - executes msgs signed by granters on behalf of the grantee
+type MsgExec struct {
+	Grantee sdk.Address `json:"grantee"`
+	Msgs    []sdk.Msg   `json:"msgs"`
+}
+
+var _ sdk.Msg = MsgExec{}
+
+func NewMsgExec(grantee sdk.Address, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+//nolint
+func (msg MsgExec) Type() string              { return MsgType }
+func (msg MsgExec) GetSigners() []sdk.Address { return []sdk.Address{msg.Grantee} }
+
+// Implements Msg.
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrInvalidExecMsg(DefaultCodespace, "no msgs to execute")
+	}
+	for _, m := range msg.Msgs {
+		if _, ok := m.(MsgExec); ok {
+			return ErrInvalidExecMsg(DefaultCodespace, "cannot nest MsgExec")
+		}
+		if len(m.GetSigners()) != 1 {
+			return ErrInvalidExecMsg(DefaultCodespace, "executed msgs must have exactly one signer")
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	var msgs []json.RawMessage
+	for _, m := range msg.Msgs {
+		msgs = append(msgs, json.RawMessage(m.GetSignBytes()))
+	}
+	b, err := msgCdc.MarshalJSON(struct {
+		Grantee string            `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		Msgs:    msgs,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,58 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// test ValidateBasic for MsgGrant
+func TestMsgGrant(t *testing.T) {
+	coins := sdk.Coins{sdk.NewCoin("steak", 10)}
+	tests := []struct {
+		granter, grantee sdk.Address
+		authorization    Authorization
+		expectPass       bool
+	}{
+		{granter, grantee, SendAuthorization{coins}, true},
+		{granter, grantee, GenericAuthorization{"gov/MsgDeposit"}, true},
+		{granter, grantee, VoteAuthorization{}, true},
+		{granter, grantee, RedelegateAuthorization{}, true},
+		{granter, granter, SendAuthorization{coins}, false},
+		{sdk.Address{}, grantee, SendAuthorization{coins}, false},
+		{granter, grantee, nil, false},
+		{granter, grantee, GenericAuthorization{""}, false},
+		{granter, grantee, GenericAuthorization{"gov"}, false},
+		{granter, grantee, GenericAuthorization{MsgName(MsgGrant{})}, false},
+		{granter, grantee, GenericAuthorization{MsgName(MsgExec{})}, false},
+		{granter, grantee, SendAuthorization{sdk.Coins{}}, false},
+		{granter, grantee, SendAuthorization{sdk.Coins{sdk.NewCoin("steak", -10)}}, false},
+		{granter, grantee, SendAuthorization{sdk.Coins{sdk.NewCoin("steak", 0)}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgGrant(tc.granter, tc.grantee, tc.authorization, 0)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestGrantKeys(t *testing.T) {
+	// the boundary between granter and grantee is part of the key
+	require.NotEqual(t,
+		GetGrantsKey(sdk.Address([]byte("ab")), sdk.Address([]byte("c"))),
+		GetGrantsKey(sdk.Address([]byte("a")), sdk.Address([]byte("bc"))))
+	require.NotEqual(t,
+		GetGrantKey(sdk.Address([]byte("a")), sdk.Address([]byte("b")), "cbank"),
+		GetGrantKey(sdk.Address([]byte("a")), sdk.Address([]byte("bc")), "bank"))
+}
+
+func TestMsgName(t *testing.T) {
+	require.Equal(t, "authz/MsgGrant", MsgName(MsgGrant{}))
+	require.Equal(t, "authz/MsgRevoke", MsgName(&MsgRevoke{}))
+}
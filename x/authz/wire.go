@@ -0,0 +1,24 @@
+package authz
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgGrant{}, "cosmos-sdk/MsgGrant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "cosmos-sdk/MsgRevoke", nil)
+	cdc.RegisterConcrete(MsgExec{}, "cosmos-sdk/MsgExec", nil)
+
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(GenericAuthorization{}, "authz/GenericAuthorization", nil)
+	cdc.RegisterConcrete(SendAuthorization{}, "authz/SendAuthorization", nil)
+	cdc.RegisterConcrete(VoteAuthorization{}, "authz/VoteAuthorization", nil)
+	cdc.RegisterConcrete(RedelegateAuthorization{}, "authz/RedelegateAuthorization", nil)
+}
+
+var msgCdc = wire.NewCodec()
+
+func init() {
+	RegisterWire(msgCdc)
+}
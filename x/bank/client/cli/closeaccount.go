@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	flagBeneficiary = "beneficiary"
+)
+
+// CloseAccountCmd will create a tx closing the account of the given key,
+// sending its remaining coins to the beneficiary
+func CloseAccountCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close-account",
+		Short: "Close an account, sending its remaining coins to a beneficiary",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			from, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			beneficiary, err := sdk.GetAccAddressBech32(viper.GetString(flagBeneficiary))
+			if err != nil {
+				return err
+			}
+
+			msg := bank.NewMsgCloseAccount(from, beneficiary)
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagBeneficiary, "", "Address receiving the remaining coins of the closed account")
+
+	return cmd
+}
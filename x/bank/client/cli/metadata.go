@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/bank/client"
+)
+
+// GetDenomMetadataCmd returns a query command displaying the metadata of a
+// denom, or of all the denoms when none is given
+func GetDenomMetadataCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "denom-metadata [denom]",
+		Short: "Query the display units of a denom, or of all denoms",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+
+			var result interface{}
+			if len(args) == 1 {
+				metadata, found, err := client.QueryDenomMetadata(ctx, cdc, storeName, args[0])
+				if err != nil {
+					return err
+				}
+				if !found {
+					return fmt.Errorf("no metadata for denom %s", args[0])
+				}
+				result = metadata
+			} else {
+				kvs, err := ctx.QuerySubspace(cdc, bank.DenomMetadataKeyPrefix, storeName)
+				if err != nil {
+					return err
+				}
+				all := []bank.Metadata{}
+				for _, kv := range kvs {
+					var metadata bank.Metadata
+					err = cdc.UnmarshalBinary(kv.Value, &metadata)
+					if err != nil {
+						return err
+					}
+					all = append(all, metadata)
+				}
+				result = all
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, result)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+// GetBalanceCmd returns a query command displaying the coins of an account in
+// the display units of their denoms
+func GetBalanceCmd(accStoreName string, bankStoreName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	return &cobra.Command{
+		Use:   "balance [address]",
+		Short: "Query the coins of an account in display units",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(auth.AddressStoreKey(addr), accStoreName)
+			if err != nil {
+				return err
+			}
+			if res == nil {
+				return sdk.ErrUnknownAddress("No account with address " + args[0] + " was found in the state.")
+			}
+
+			account, err := decoder(res)
+			if err != nil {
+				return err
+			}
+
+			balance, err := client.FormatDisplayCoins(ctx, cdc, bankStoreName, account.GetCoins())
+			if err != nil {
+				return err
+			}
+			fmt.Println(balance)
+			return nil
+		},
+	}
+}
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,6 +14,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/bank/client"
 )
 
@@ -17,6 +22,10 @@ const (
 	flagTo     = "to"
 	flagAmount = "amount"
 	flagAsync  = "async"
+	flagCSV    = "csv"
+
+	// bank state is kept in the bank store
+	storeName = "bank"
 )
 
 // SendTxCommand will create a send tx and sign it with the given key
@@ -33,21 +42,33 @@ func SendTxCmd(cdc *wire.Codec) *cobra.Command {
 				return err
 			}
 
-			toStr := viper.GetString(flagTo)
+			// build and sign the transaction, then broadcast to Tendermint
+			var msg sdk.Msg
+			if csvPath := viper.GetString(flagCSV); csvPath != "" {
+				if viper.GetString(flagTo) != "" || viper.GetString(flagAmount) != "" {
+					return errors.New("--csv cannot be combined with --to or --amount")
+				}
+				outputs, err := readOutputsCSV(ctx, cdc, csvPath)
+				if err != nil {
+					return err
+				}
+				msg = client.BuildMultiSendMsg(from, outputs)
+			} else {
+				toStr := viper.GetString(flagTo)
 
-			to, err := sdk.GetAccAddressBech32(toStr)
-			if err != nil {
-				return err
-			}
-			// parse coins
-			amount := viper.GetString(flagAmount)
-			coins, err := sdk.ParseCoins(amount)
-			if err != nil {
-				return err
-			}
+				to, err := sdk.GetAccAddressBech32(toStr)
+				if err != nil {
+					return err
+				}
+				// parse coins
+				amount := viper.GetString(flagAmount)
+				coins, err := client.ParseDisplayCoins(ctx, cdc, storeName, amount)
+				if err != nil {
+					return err
+				}
 
-			// build and sign the transaction, then broadcast to Tendermint
-			msg := client.BuildMsg(from, to, coins)
+				msg = client.BuildMsg(from, to, coins)
+			}
 
 			if viper.GetBool(flagAsync) {
 				res, err := ctx.EnsureSignBuildBroadcastAsync(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -68,8 +89,50 @@ func SendTxCmd(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagTo, "", "Address to send coins")
-	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
+	cmd.Flags().String(flagAmount, "", "Amount of coins to send, in base or display units (eg. 1.5atom)")
 	cmd.Flags().Bool(flagAsync, false, "Pass the async flag to send a tx without waiting for the tx to be included in a block")
+	cmd.Flags().String(flagCSV, "", "CSV file of recipients, one \"address,amount[,memo]\" per line, all paid in a single tx")
 
 	return cmd
 }
+
+// read the outputs of a multi-send from a CSV file of "address,amount[,memo]" lines
+func readOutputsCSV(ctx context.CoreContext, cdc *wire.Codec, path string) ([]bank.Output, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []bank.Output
+	for i, record := range records {
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("line %d: expected address,amount[,memo]", i+1)
+		}
+		to, err := sdk.GetAccAddressBech32(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		coins, err := client.ParseDisplayCoins(ctx, cdc, storeName, record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		output := bank.NewOutput(to, coins)
+		if len(record) == 3 {
+			output.Memo = record[2]
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("no recipients in the CSV file")
+	}
+	return outputs, nil
+}
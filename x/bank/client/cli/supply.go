@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// GetSupplyCmd returns a query command displaying the total supply of coins
+func GetSupplyCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "supply",
+		Short: "Query the total supply of coins",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(bank.SupplyKey, storeName)
+			if err != nil {
+				return err
+			}
+
+			supply := sdk.Coins{}
+			if len(res) != 0 {
+				err = cdc.UnmarshalBinary(res, &supply)
+				if err != nil {
+					return err
+				}
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, supply)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
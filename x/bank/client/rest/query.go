@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/bank/client"
+)
+
+// QuerySupplyRequestHandlerFn - http request handler to query the total supply of coins
+func QuerySupplyRequestHandlerFn(cdc *wire.Codec, ctx context.CoreContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := ctx.QueryStore(bank.SupplyKey, storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query supply. Error: %s", err.Error())))
+			return
+		}
+
+		supply := sdk.Coins{}
+		if len(res) != 0 {
+			err = cdc.UnmarshalBinary(res, &supply)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("couldn't parse query result. Result: %s. Error: %s", res, err.Error())))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(supply)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// QueryDenomMetadataRequestHandlerFn - http request handler to query the metadata of a denom
+func QueryDenomMetadataRequestHandlerFn(cdc *wire.Codec, ctx context.CoreContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		denom := mux.Vars(r)["denom"]
+
+		metadata, found, err := client.QueryDenomMetadata(ctx, cdc, storeName, denom)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query denom metadata. Error: %s", err.Error())))
+			return
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("no metadata for denom %s", denom)))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(metadata)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
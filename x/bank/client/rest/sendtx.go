@@ -17,6 +17,8 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	r.HandleFunc("/accounts/{address}/send", SendRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	r.HandleFunc("/supply", QuerySupplyRequestHandlerFn(cdc, ctx, "bank")).Methods("GET")
+	r.HandleFunc("/denoms/{denom}/metadata", QueryDenomMetadataRequestHandlerFn(cdc, ctx, "bank")).Methods("GET")
 }
 
 type sendBody struct {
@@ -28,6 +30,7 @@ type sendBody struct {
 	ChainID          string    `json:"chain_id"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
+	TimeoutHeight    int64     `json:"timeout_height"`
 	Gas              int64     `json:"gas"`
 }
 
@@ -95,6 +98,7 @@ func SendRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreCont
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
@@ -1,10 +1,20 @@
 package client
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
 	bank "github.com/cosmos/cosmos-sdk/x/bank"
+	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
 )
 
+// a coin whose amount may be a decimal of a display unit, eg. 1.5atom
+var reDisplayCoin = regexp.MustCompile(`^([[:digit:]]+(?:\.[[:digit:]]+)?)[[:space:]]*([[:alpha:]][[:alnum:]]{2,15})$`)
+
 // build the sendTx msg
 func BuildMsg(from sdk.Address, to sdk.Address, coins sdk.Coins) sdk.Msg {
 	input := bank.NewInput(from, coins)
@@ -12,3 +22,142 @@ func BuildMsg(from sdk.Address, to sdk.Address, coins sdk.Coins) sdk.Msg {
 	msg := bank.NewMsgSend([]bank.Input{input}, []bank.Output{output})
 	return msg
 }
+
+// build a sendTx msg paying all the outputs from a single input
+func BuildMultiSendMsg(from sdk.Address, outputs []bank.Output) sdk.Msg {
+	total := sdk.Coins{}
+	for _, output := range outputs {
+		total = total.Plus(output.Coins)
+	}
+	input := bank.NewInput(from, total)
+	msg := bank.NewMsgSend([]bank.Input{input}, outputs)
+	return msg
+}
+
+// QueryDenomMetadata queries the metadata of a base denom or of any of its units
+func QueryDenomMetadata(ctx context.CoreContext, cdc *wire.Codec, storeName string, denom string) (metadata bank.Metadata, found bool, err error) {
+	res, err := ctx.QueryStore(bank.DenomMetadataKey(denom), storeName)
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		base, err := ctx.QueryStore(bank.DenomUnitKey(denom), storeName)
+		if err != nil || len(base) == 0 {
+			return metadata, false, err
+		}
+		res, err = ctx.QueryStore(bank.DenomMetadataKey(string(base)), storeName)
+		if err != nil || len(res) == 0 {
+			return metadata, false, err
+		}
+	}
+
+	err = cdc.UnmarshalBinary(res, &metadata)
+	if err != nil {
+		return
+	}
+	return metadata, true, nil
+}
+
+// ParseDisplayCoins parses coins like sdk.ParseCoins, but also accepts decimal
+// amounts of the units of denoms with metadata (eg. 1.5atom) converted to base units
+func ParseDisplayCoins(ctx context.CoreContext, cdc *wire.Codec, storeName string, coinsStr string) (sdk.Coins, error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	var coins sdk.Coins
+	for _, coinStr := range strings.Split(coinsStr, ",") {
+		matches := reDisplayCoin.FindStringSubmatch(strings.TrimSpace(coinStr))
+		if matches == nil {
+			return nil, fmt.Errorf("invalid coin expression: %s", coinStr)
+		}
+		amount, denom := matches[1], matches[2]
+
+		metadata, found, err := QueryDenomMetadata(ctx, cdc, storeName, denom)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			coin, err := sdk.ParseCoin(coinStr)
+			if err != nil {
+				return nil, err
+			}
+			coins = append(coins, coin)
+			continue
+		}
+
+		base, err := metadata.ToBase(amount, denom)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, sdk.Coin{metadata.Base, base})
+	}
+
+	coins.Sort()
+	if !coins.IsValid() {
+		return nil, fmt.Errorf("parseCoins invalid: %#v", coins)
+	}
+	return coins, nil
+}
+
+// FormatDisplayCoins formats coins in the display units of the denoms with metadata
+func FormatDisplayCoins(ctx context.CoreContext, cdc *wire.Codec, storeName string, coins sdk.Coins) (string, error) {
+	strs := make([]string, len(coins))
+	for i, coin := range coins {
+		metadata, found, err := QueryDenomMetadata(ctx, cdc, storeName, coin.Denom)
+		if err != nil {
+			return "", err
+		}
+		if found && metadata.Base == coin.Denom {
+			strs[i] = metadata.FormatDisplay(coin.Amount)
+		} else {
+			strs[i] = coin.String()
+		}
+	}
+	return strings.Join(strs, ","), nil
+}
+
+// ParamsQuerier returns the querier of the bank params kept in the bank store,
+// listed with the keys and JSON encoded values used to change them
+func ParamsQuerier(storeName string) paramsclient.ModuleParamsQuerier {
+	return func(ctx context.CoreContext, cdc *wire.Codec) ([]paramsclient.Param, error) {
+		all := []paramsclient.Param{}
+
+		kvs, err := ctx.QuerySubspace(cdc, bank.SendEnabledKeyPrefix, storeName)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			var enabled bool
+			err = cdc.UnmarshalBinary(kv.Value, &enabled)
+			if err != nil {
+				return nil, err
+			}
+			value, err := cdc.MarshalJSON(enabled)
+			if err != nil {
+				return nil, err
+			}
+			denom := string(kv.Key[len(bank.SendEnabledKeyPrefix):])
+			all = append(all, paramsclient.Param{Subspace: "bank", Key: bank.ParamSendEnabledKey(denom), Value: string(value)})
+		}
+
+		kvs, err = ctx.QuerySubspace(cdc, bank.DenomMetadataKeyPrefix, storeName)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			var metadata bank.Metadata
+			err = cdc.UnmarshalBinary(kv.Value, &metadata)
+			if err != nil {
+				return nil, err
+			}
+			value, err := cdc.MarshalJSON(metadata)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, paramsclient.Param{Subspace: "bank", Key: bank.ParamDenomMetadata, Value: string(value)})
+		}
+		return all, nil
+	}
+}
@@ -2,6 +2,8 @@
 package bank
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,6 +13,13 @@ const (
 
 	CodeInvalidInput  sdk.CodeType = 101
 	CodeInvalidOutput sdk.CodeType = 102
+	CodeCloseAccount  sdk.CodeType = 103
+
+	CodeUnknownModuleAccount sdk.CodeType = 104
+	CodeModulePermission     sdk.CodeType = 105
+	CodeSendDisabled         sdk.CodeType = 106
+	CodeInvalidMetadata      sdk.CodeType = 107
+	CodeBlockedAddr          sdk.CodeType = 108
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -20,6 +29,18 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid input coins"
 	case CodeInvalidOutput:
 		return "invalid output coins"
+	case CodeCloseAccount:
+		return "account cannot be closed"
+	case CodeUnknownModuleAccount:
+		return "unknown module account"
+	case CodeModulePermission:
+		return "module account lacks the permission"
+	case CodeSendDisabled:
+		return "transfers of the denom are disabled"
+	case CodeInvalidMetadata:
+		return "invalid denom metadata"
+	case CodeBlockedAddr:
+		return "recipient address is blocked"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -44,6 +65,30 @@ func ErrNoOutputs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidOutput, "")
 }
 
+func ErrCloseAccount(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeCloseAccount, msg)
+}
+
+func ErrUnknownModuleAccount(codespace sdk.CodespaceType, name string) sdk.Error {
+	return newError(codespace, CodeUnknownModuleAccount, fmt.Sprintf("unknown module account %s", name))
+}
+
+func ErrModulePermission(codespace sdk.CodespaceType, name string, permission string) sdk.Error {
+	return newError(codespace, CodeModulePermission, fmt.Sprintf("module account %s lacks the %s permission", name, permission))
+}
+
+func ErrSendDisabled(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeSendDisabled, fmt.Sprintf("transfers of %s are disabled", denom))
+}
+
+func ErrInvalidMetadata(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidMetadata, msg)
+}
+
+func ErrBlockedAddr(codespace sdk.CodespaceType, addr sdk.Address) sdk.Error {
+	return newError(codespace, CodeBlockedAddr, fmt.Sprintf("%s is not allowed to receive coins", addr))
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
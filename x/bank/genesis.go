@@ -0,0 +1,61 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SendEnabled - whether transfers of a denom are enabled
+type SendEnabled struct {
+	Denom   string `json:"denom"`
+	Enabled bool   `json:"enabled"`
+}
+
+// GenesisState - all bank state that must be provided at genesis
+type GenesisState struct {
+	SendEnabled   []SendEnabled `json:"send_enabled"` // denoms not listed are enabled
+	DenomMetadata []Metadata    `json:"denom_metadata"`
+}
+
+func NewGenesisState(sendEnabled []SendEnabled, denomMetadata []Metadata) GenesisState {
+	return GenesisState{
+		SendEnabled:   sendEnabled,
+		DenomMetadata: denomMetadata,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		SendEnabled:   []SendEnabled{},
+		DenomMetadata: []Metadata{},
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, se := range data.SendEnabled {
+		keeper.SetSendEnabled(ctx, se.Denom, se.Enabled)
+	}
+	for _, metadata := range data.DenomMetadata {
+		err := keeper.SetDenomMetadata(ctx, metadata)
+		if err != nil {
+			// TODO: Handle this with #870
+			panic(err)
+		}
+	}
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	sendEnabled := []SendEnabled{}
+	keeper.IterateSendEnabled(ctx, func(denom string, enabled bool) (stop bool) {
+		sendEnabled = append(sendEnabled, SendEnabled{denom, enabled})
+		return false
+	})
+	denomMetadata := []Metadata{}
+	keeper.IterateDenomMetadata(ctx, func(metadata Metadata) (stop bool) {
+		denomMetadata = append(denomMetadata, metadata)
+		return false
+	})
+	return NewGenesisState(sendEnabled, denomMetadata)
+}
@@ -14,6 +14,8 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgSend(ctx, k, msg)
 		case MsgIssue:
 			return handleMsgIssue(ctx, k, msg)
+		case MsgCloseAccount:
+			return handleMsgCloseAccount(ctx, k, msg)
 		default:
 			errMsg := "Unrecognized bank Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -35,6 +37,18 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 	}
 }
 
+// Handle MsgCloseAccount.
+func handleMsgCloseAccount(ctx sdk.Context, k Keeper, msg MsgCloseAccount) sdk.Result {
+	tags, err := k.CloseAccount(ctx, msg.Address, msg.Beneficiary)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
 // Handle MsgIssue.
 func handleMsgIssue(ctx sdk.Context, k Keeper, msg MsgIssue) sdk.Result {
 	panic("not implemented yet")
@@ -0,0 +1,43 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SendHooks are called by the Keeper around transfers between accounts,
+// allowing other modules to react to them or refuse them
+type SendHooks interface {
+	// called before the coins are moved, an error aborts the transfer. Not
+	// called for the transfers out of module accounts, which can't be refused.
+	BeforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error
+	// called once the coins have been moved
+	AfterSend(ctx sdk.Context, inputs []Input, outputs []Output)
+}
+
+// MultiSendHooks combines the send hooks of several modules, called in order
+type MultiSendHooks []SendHooks
+
+var _ SendHooks = MultiSendHooks{}
+
+// NewMultiSendHooks returns the combination of the given hooks
+func NewMultiSendHooks(hooks ...SendHooks) MultiSendHooks {
+	return hooks
+}
+
+// BeforeSend calls the hooks in order, stopping at the first error
+func (mh MultiSendHooks) BeforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error {
+	for _, hooks := range mh {
+		err := hooks.BeforeSend(ctx, inputs, outputs)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterSend calls the hooks in order
+func (mh MultiSendHooks) AfterSend(ctx sdk.Context, inputs []Input, outputs []Output) {
+	for _, hooks := range mh {
+		hooks.AfterSend(ctx, inputs, outputs)
+	}
+}
@@ -0,0 +1,24 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SupplyInvariant checks that the coins of all accounts, including the module
+// accounts, add up to the recorded supply
+func SupplyInvariant(ctx sdk.Context, keeper Keeper) error {
+	total := sdk.Coins{}
+	keeper.am.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		total = total.Plus(acc.GetCoins())
+		return false
+	})
+
+	supply := keeper.GetSupply(ctx)
+	if !supply.Minus(total).IsZero() {
+		return fmt.Errorf("supply %v doesn't match the %v held by accounts", supply, total)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
@@ -17,12 +18,73 @@ const (
 
 // Keeper manages transfers between accounts
 type Keeper struct {
+	// the bank store, keeping the supply, the send enabled flags and the
+	// metadata of the denoms
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
 	am auth.AccountMapper
+
+	// delegations preventing accounts from being closed, may be nil
+	ds sdk.DelegationSet
+
+	// permissions of the module accounts, by module name
+	modulePermissions map[string][]string
+
+	// hooks called around transfers, may be nil
+	hooks SendHooks
+
+	// addresses which can't receive coins through SendCoins and InputOutputCoins
+	blockedAddrs map[string]bool
 }
 
 // NewKeeper returns a new Keeper
-func NewKeeper(am auth.AccountMapper) Keeper {
-	return Keeper{am: am}
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, am auth.AccountMapper) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		am:       am,
+	}
+}
+
+// WithDelegationSet returns a copy of the keeper which refuses to close
+// accounts that still have delegations in ds
+func (keeper Keeper) WithDelegationSet(ds sdk.DelegationSet) Keeper {
+	keeper.ds = ds
+	return keeper
+}
+
+// WithModuleAccounts returns a copy of the keeper which can send coins to and
+// from the module accounts of the given names, declared with the given permissions
+func (keeper Keeper) WithModuleAccounts(modulePermissions map[string][]string) Keeper {
+	keeper.modulePermissions = modulePermissions
+	return keeper
+}
+
+// WithHooks returns a copy of the keeper which calls hooks around transfers
+// between accounts
+func (keeper Keeper) WithHooks(hooks SendHooks) Keeper {
+	keeper.hooks = hooks
+	return keeper
+}
+
+// WithBlockedAddrs returns a copy of the keeper which refuses sends to the
+// given addresses, module accounts can still move coins to them
+func (keeper Keeper) WithBlockedAddrs(addrs ...sdk.Address) Keeper {
+	blockedAddrs := make(map[string]bool, len(keeper.blockedAddrs)+len(addrs))
+	for addr := range keeper.blockedAddrs {
+		blockedAddrs[addr] = true
+	}
+	for _, addr := range addrs {
+		blockedAddrs[string(addr)] = true
+	}
+	keeper.blockedAddrs = blockedAddrs
+	return keeper
+}
+
+// BlockedAddr returns whether the addr is refused as a recipient of sends
+func (keeper Keeper) BlockedAddr(addr sdk.Address) bool {
+	return keeper.blockedAddrs[string(addr)]
 }
 
 // GetCoins returns the coins at the addr.
@@ -30,8 +92,8 @@ func (keeper Keeper) GetCoins(ctx sdk.Context, addr sdk.Address) sdk.Coins {
 	return getCoins(ctx, keeper.am, addr)
 }
 
-// SetCoins sets the coins at the addr.
-func (keeper Keeper) SetCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) sdk.Error {
+// sets the coins at the addr, without changing the supply
+func (keeper Keeper) setCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) sdk.Error {
 	return setCoins(ctx, keeper.am, addr, amt)
 }
 
@@ -40,78 +102,381 @@ func (keeper Keeper) HasCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins)
 	return hasCoins(ctx, keeper.am, addr, amt)
 }
 
-// SubtractCoins subtracts amt from the coins at the addr.
-func (keeper Keeper) SubtractCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+// subtracts amt from the coins at the addr, without changing the supply
+func (keeper Keeper) subtractCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	return subtractCoins(ctx, keeper.am, addr, amt)
 }
 
-// AddCoins adds amt to the coins at the addr.
-func (keeper Keeper) AddCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+// adds amt to the coins at the addr, without changing the supply
+func (keeper Keeper) addCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	return addCoins(ctx, keeper.am, addr, amt)
 }
 
 // SendCoins moves coins from one account to another
 func (keeper Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
-	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
+	if keeper.BlockedAddr(toAddr) {
+		return nil, ErrBlockedAddr(DefaultCodespace, toAddr)
+	}
+	err := keeper.checkSendEnabled(ctx, amt)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoins(ctx, fromAddr, toAddr, amt)
 }
 
 // InputOutputCoins handles a list of inputs and outputs
 func (keeper Keeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
-	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
+	for _, out := range outputs {
+		if keeper.BlockedAddr(out.Address) {
+			return nil, ErrBlockedAddr(DefaultCodespace, out.Address)
+		}
+	}
+
+	for _, in := range inputs {
+		err := keeper.checkSendEnabled(ctx, in.Coins)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if keeper.hooks != nil {
+		err := keeper.hooks.BeforeSend(ctx, inputs, outputs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tags, err := inputOutputCoins(ctx, keeper.am, inputs, outputs)
+	if err != nil {
+		return nil, err
+	}
+	if keeper.hooks != nil {
+		keeper.hooks.AfterSend(ctx, inputs, outputs)
+	}
+	return tags, nil
+}
+
+// GetSupply returns the total supply of coins
+func (keeper Keeper) GetSupply(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(SupplyKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var supply sdk.Coins
+	keeper.cdc.MustUnmarshalBinary(bz, &supply)
+	return supply
+}
+
+// SetSupply sets the total supply of coins, to be used at genesis
+func (keeper Keeper) SetSupply(ctx sdk.Context, supply sdk.Coins) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(SupplyKey, keeper.cdc.MustMarshalBinary(supply))
+}
+
+// MintCoins creates amt new coins at the addr, increasing the supply
+func (keeper Keeper) MintCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	newCoins, tags, err := addCoins(ctx, keeper.am, addr, amt)
+	if err != nil {
+		return amt, nil, err
+	}
+	keeper.SetSupply(ctx, keeper.GetSupply(ctx).Plus(amt))
+	return newCoins, tags, nil
+}
+
+// BurnCoins destroys amt coins from the addr, decreasing the supply
+func (keeper Keeper) BurnCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	newCoins, tags, err := subtractCoins(ctx, keeper.am, addr, amt)
+	if err != nil {
+		return amt, nil, err
+	}
+	keeper.SetSupply(ctx, keeper.GetSupply(ctx).Minus(amt))
+	return newCoins, tags, nil
+}
+
+// GetSendEnabled returns whether transfers of the denom are enabled, which
+// they are unless disabled
+func (keeper Keeper) GetSendEnabled(ctx sdk.Context, denom string) bool {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(SendEnabledKey(denom))
+	if bz == nil {
+		return true
+	}
+	var enabled bool
+	keeper.cdc.MustUnmarshalBinary(bz, &enabled)
+	return enabled
+}
+
+// SetSendEnabled enables or disables transfers of the denom
+func (keeper Keeper) SetSendEnabled(ctx sdk.Context, denom string, enabled bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(SendEnabledKey(denom), keeper.cdc.MustMarshalBinary(enabled))
+}
+
+// IterateSendEnabled iterates over the denoms with an explicitly set send
+// enabled flag
+func (keeper Keeper) IterateSendEnabled(ctx sdk.Context, process func(denom string, enabled bool) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, SendEnabledKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var enabled bool
+		keeper.cdc.MustUnmarshalBinary(iter.Value(), &enabled)
+		if process(string(iter.Key()[len(SendEnabledKeyPrefix):]), enabled) {
+			return
+		}
+	}
+}
+
+// fails if transfers of any of the coins are disabled
+func (keeper Keeper) checkSendEnabled(ctx sdk.Context, amt sdk.Coins) sdk.Error {
+	for _, coin := range amt {
+		if !keeper.GetSendEnabled(ctx, coin.Denom) {
+			return ErrSendDisabled(DefaultCodespace, coin.Denom)
+		}
+	}
+	return nil
+}
+
+// GetModuleAccount returns the account of a declared module, creating it if needed
+func (keeper Keeper) GetModuleAccount(ctx sdk.Context, name string) (*auth.ModuleAccount, sdk.Error) {
+	permissions, ok := keeper.modulePermissions[name]
+	if !ok {
+		return nil, ErrUnknownModuleAccount(DefaultCodespace, name)
+	}
+	return keeper.am.GetModuleAccount(ctx, name, permissions...), nil
+}
+
+// GetModuleCoins returns the coins held by a module account
+func (keeper Keeper) GetModuleCoins(ctx sdk.Context, name string) sdk.Coins {
+	return getCoins(ctx, keeper.am, auth.NewModuleAddress(name))
+}
+
+// SendCoinsFromModuleToAccount moves coins out of a module account
+func (keeper Keeper) SendCoinsFromModuleToAccount(ctx sdk.Context, name string, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.GetModuleAccount(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoinsFromModule(ctx, macc.Address, toAddr, amt)
+}
+
+// SendCoinsFromAccountToModule moves coins into a module account
+func (keeper Keeper) SendCoinsFromAccountToModule(ctx sdk.Context, fromAddr sdk.Address, name string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.GetModuleAccount(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoins(ctx, fromAddr, macc.Address, amt)
+}
+
+// SendCoinsFromModuleToModule moves coins between module accounts
+func (keeper Keeper) SendCoinsFromModuleToModule(ctx sdk.Context, senderName string, recipientName string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	sender, err := keeper.GetModuleAccount(ctx, senderName)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := keeper.GetModuleAccount(ctx, recipientName)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoinsFromModule(ctx, sender.Address, recipient.Address, amt)
+}
+
+// DelegateCoinsFromAccountToModule escrows delegated coins in a module
+// account with the staking permission
+func (keeper Keeper) DelegateCoinsFromAccountToModule(ctx sdk.Context, fromAddr sdk.Address, name string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.getModuleAccountWithPermission(ctx, name, auth.Staking)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoins(ctx, fromAddr, macc.Address, amt)
+}
+
+// UndelegateCoinsFromModuleToAccount returns escrowed delegated coins from a
+// module account with the staking permission
+func (keeper Keeper) UndelegateCoinsFromModuleToAccount(ctx sdk.Context, name string, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.getModuleAccountWithPermission(ctx, name, auth.Staking)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.sendCoinsFromModule(ctx, macc.Address, toAddr, amt)
+}
+
+// MintModuleCoins creates amt new coins in a module account with the minter permission
+func (keeper Keeper) MintModuleCoins(ctx sdk.Context, name string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.getModuleAccountWithPermission(ctx, name, auth.Minter)
+	if err != nil {
+		return nil, err
+	}
+	_, tags, err := keeper.MintCoins(ctx, macc.Address, amt)
+	return tags, err
+}
+
+// BurnModuleCoins destroys amt coins held by a module account with the burner permission
+func (keeper Keeper) BurnModuleCoins(ctx sdk.Context, name string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	macc, err := keeper.getModuleAccountWithPermission(ctx, name, auth.Burner)
+	if err != nil {
+		return nil, err
+	}
+	_, tags, err := keeper.BurnCoins(ctx, macc.Address, amt)
+	return tags, err
+}
+
+func (keeper Keeper) getModuleAccountWithPermission(ctx sdk.Context, name string, permission string) (*auth.ModuleAccount, sdk.Error) {
+	macc, err := keeper.GetModuleAccount(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !macc.HasPermission(permission) {
+		return nil, ErrModulePermission(DefaultCodespace, name, permission)
+	}
+	return macc, nil
+}
+
+// CloseAccount sends all the coins at addr to the beneficiary and removes the account
+func (keeper Keeper) CloseAccount(ctx sdk.Context, addr sdk.Address, beneficiary sdk.Address) (sdk.Tags, sdk.Error) {
+	if keeper.BlockedAddr(beneficiary) {
+		return nil, ErrBlockedAddr(DefaultCodespace, beneficiary)
+	}
+	return keeper.closeAccount(ctx, addr, beneficiary)
+}
+
+// moves coins between accounts, calling the hooks around the transfer
+func (keeper Keeper) sendCoins(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	if keeper.hooks == nil {
+		return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
+	}
+
+	inputs := []Input{NewInput(fromAddr, amt)}
+	outputs := []Output{NewOutput(toAddr, amt)}
+	err := keeper.hooks.BeforeSend(ctx, inputs, outputs)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
+	if err != nil {
+		return nil, err
+	}
+	keeper.hooks.AfterSend(ctx, inputs, outputs)
+	return tags, nil
+}
+
+// moves coins out of a module account, the hooks are told about the transfer
+// but cannot refuse it as modules pay out from their block logic
+func (keeper Keeper) sendCoinsFromModule(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	tags, err := sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
+	if err != nil {
+		return nil, err
+	}
+	if keeper.hooks != nil {
+		keeper.hooks.AfterSend(ctx, []Input{NewInput(fromAddr, amt)}, []Output{NewOutput(toAddr, amt)})
+	}
+	return tags, nil
+}
+
+// sends the remaining coins to the beneficiary and removes the account
+// NOTE: Make sure to revert state changes from tx on error
+func (keeper Keeper) closeAccount(ctx sdk.Context, addr sdk.Address, beneficiary sdk.Address) (sdk.Tags, sdk.Error) {
+	acc := keeper.am.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(addr.String())
+	}
+
+	if keeper.ds != nil {
+		hasDelegations := false
+		keeper.ds.IterateDelegations(ctx, addr, func(_ int64, _ sdk.Delegation) (stop bool) {
+			hasDelegations = true
+			return true
+		})
+		if hasDelegations {
+			return nil, ErrCloseAccount(DefaultCodespace, "account still has delegations")
+		}
+	}
+
+	tags := sdk.NewTags("closed", []byte(addr.String()))
+	coins := acc.GetCoins()
+	if !coins.IsZero() {
+		err := keeper.checkSendEnabled(ctx, coins)
+		if err != nil {
+			return nil, err
+		}
+		// the remaining coins go through the send hooks like any other transfer
+		sendTags, err := keeper.sendCoins(ctx, addr, beneficiary, coins)
+		if err != nil {
+			return nil, err
+		}
+		tags = tags.AppendTags(sendTags)
+	}
+
+	// reload the account now that its coins have been sent
+	keeper.am.RemoveAccount(ctx, keeper.am.GetAccount(ctx, addr))
+	return tags, nil
 }
 
 //______________________________________________________________________________________________
 
 // SendKeeper only allows transfers between accounts, without the possibility of creating coins
 type SendKeeper struct {
-	am auth.AccountMapper
+	keeper Keeper
 }
 
-// NewSendKeeper returns a new Keeper
-func NewSendKeeper(am auth.AccountMapper) SendKeeper {
-	return SendKeeper{am: am}
+// NewSendKeeper returns a keeper only moving coins between accounts with the
+// keeper
+func NewSendKeeper(keeper Keeper) SendKeeper {
+	return SendKeeper{keeper: keeper}
 }
 
 // GetCoins returns the coins at the addr.
 func (keeper SendKeeper) GetCoins(ctx sdk.Context, addr sdk.Address) sdk.Coins {
-	return getCoins(ctx, keeper.am, addr)
+	return keeper.keeper.GetCoins(ctx, addr)
 }
 
 // HasCoins returns whether or not an account has at least amt coins.
 func (keeper SendKeeper) HasCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) bool {
-	return hasCoins(ctx, keeper.am, addr, amt)
+	return keeper.keeper.HasCoins(ctx, addr, amt)
 }
 
 // SendCoins moves coins from one account to another
 func (keeper SendKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
-	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
+	return keeper.keeper.SendCoins(ctx, fromAddr, toAddr, amt)
 }
 
 // InputOutputCoins handles a list of inputs and outputs
 func (keeper SendKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
-	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
+	return keeper.keeper.InputOutputCoins(ctx, inputs, outputs)
 }
 
 //______________________________________________________________________________________________
 
 // ViewKeeper only allows reading of balances
 type ViewKeeper struct {
-	am auth.AccountMapper
+	keeper Keeper
 }
 
-// NewViewKeeper returns a new Keeper
-func NewViewKeeper(am auth.AccountMapper) ViewKeeper {
-	return ViewKeeper{am: am}
+// NewViewKeeper returns a keeper only reading the balances and the bank state
+// of the keeper
+func NewViewKeeper(keeper Keeper) ViewKeeper {
+	return ViewKeeper{keeper: keeper}
 }
 
 // GetCoins returns the coins at the addr.
 func (keeper ViewKeeper) GetCoins(ctx sdk.Context, addr sdk.Address) sdk.Coins {
-	return getCoins(ctx, keeper.am, addr)
+	return keeper.keeper.GetCoins(ctx, addr)
 }
 
 // HasCoins returns whether or not an account has at least amt coins.
 func (keeper ViewKeeper) HasCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) bool {
-	return hasCoins(ctx, keeper.am, addr, amt)
+	return keeper.keeper.HasCoins(ctx, addr, amt)
+}
+
+// GetDenomMetadata returns the metadata of a base denom or of any of its units
+func (keeper ViewKeeper) GetDenomMetadata(ctx sdk.Context, denom string) (Metadata, bool) {
+	return keeper.keeper.GetDenomMetadata(ctx, denom)
+}
+
+// GetSupply returns the total supply of coins
+func (keeper ViewKeeper) GetSupply(ctx sdk.Context) sdk.Coins {
+	return keeper.keeper.GetSupply(ctx)
 }
 
 //______________________________________________________________________________________________
@@ -0,0 +1,25 @@
+package bank
+
+// nolint
+var (
+	// Keys for store prefixes
+	SupplyKey              = []byte{0x00} // key for the total supply of coins
+	SendEnabledKeyPrefix   = []byte{0x01} // prefix for each key to the send enabled flag of a denom
+	DenomMetadataKeyPrefix = []byte{0x02} // prefix for each key to the metadata of a base denom
+	DenomUnitKeyPrefix     = []byte{0x03} // prefix for each key to the base denom of a unit
+)
+
+// get the key for the send enabled flag of a denom
+func SendEnabledKey(denom string) []byte {
+	return append(SendEnabledKeyPrefix, []byte(denom)...)
+}
+
+// get the key for the metadata of a base denom
+func DenomMetadataKey(base string) []byte {
+	return append(DenomMetadataKeyPrefix, []byte(base)...)
+}
+
+// get the key for the base denom of a unit
+func DenomUnitKey(denom string) []byte {
+	return append(DenomUnitKeyPrefix, []byte(denom)...)
+}
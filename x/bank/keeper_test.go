@@ -17,24 +17,26 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey) {
 	db := dbm.NewMemDB()
 	authKey := sdk.NewKVStoreKey("authkey")
+	bankKey := sdk.NewKVStoreKey("bankkey")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
 	ms.LoadLatestVersion()
-	return ms, authKey
+	return ms, authKey, bankKey
 }
 
 func TestKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
 
 	addr := sdk.Address([]byte("addr1"))
 	addr2 := sdk.Address([]byte("addr2"))
@@ -45,7 +47,7 @@ func TestKeeper(t *testing.T) {
 	accountMapper.SetAccount(ctx, acc)
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{}))
 
-	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
 
 	// Test HasCoins
@@ -55,21 +57,21 @@ func TestKeeper(t *testing.T) {
 	require.False(t, coinKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)}))
 
 	// Test AddCoins
-	coinKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)})
+	coinKeeper.addCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 25)}))
 
-	coinKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 15)})
+	coinKeeper.addCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 15)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 15), sdk.NewCoin("foocoin", 25)}))
 
 	// Test SubtractCoins
-	coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
-	coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)})
+	coinKeeper.subtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.subtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 15)}))
 
-	coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 11)})
+	coinKeeper.subtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 11)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 15)}))
 
-	coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10)})
+	coinKeeper.subtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 15)}))
 	require.False(t, coinKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 1)}))
 
@@ -83,7 +85,7 @@ func TestKeeper(t *testing.T) {
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
 	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 5)}))
 
-	coinKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 30)})
+	coinKeeper.addCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 30)})
 	coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 5)})
 	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 20), sdk.NewCoin("foocoin", 5)}))
 	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)}))
@@ -112,15 +114,15 @@ func TestKeeper(t *testing.T) {
 }
 
 func TestSendKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
-	sendKeeper := NewSendKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+	sendKeeper := NewSendKeeper(coinKeeper)
 
 	addr := sdk.Address([]byte("addr1"))
 	addr2 := sdk.Address([]byte("addr2"))
@@ -131,7 +133,7 @@ func TestSendKeeper(t *testing.T) {
 	accountMapper.SetAccount(ctx, acc)
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{}))
 
-	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
 
 	// Test HasCoins
@@ -140,7 +142,7 @@ func TestSendKeeper(t *testing.T) {
 	require.False(t, sendKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)}))
 	require.False(t, sendKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)}))
 
-	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)})
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)})
 
 	// Test SendCoins
 	sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 5)})
@@ -152,7 +154,7 @@ func TestSendKeeper(t *testing.T) {
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
 	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 5)}))
 
-	coinKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 30)})
+	coinKeeper.addCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 30)})
 	sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 5)})
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 20), sdk.NewCoin("foocoin", 5)}))
 	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)}))
@@ -181,15 +183,15 @@ func TestSendKeeper(t *testing.T) {
 }
 
 func TestViewKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
-	viewKeeper := NewViewKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+	viewKeeper := NewViewKeeper(coinKeeper)
 
 	addr := sdk.Address([]byte("addr1"))
 	acc := accountMapper.NewAccountWithAddress(ctx, addr)
@@ -198,7 +200,7 @@ func TestViewKeeper(t *testing.T) {
 	accountMapper.SetAccount(ctx, acc)
 	require.True(t, viewKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{}))
 
-	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
 	require.True(t, viewKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
 
 	// Test HasCoins
@@ -207,3 +209,423 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)}))
 }
+
+// delegation set in which the given delegators have a single delegation
+type mockDelegationSet map[string]bool
+
+func (ds mockDelegationSet) GetValidatorSet() sdk.ValidatorSet { return nil }
+func (ds mockDelegationSet) IterateDelegations(ctx sdk.Context, delegator sdk.Address,
+	fn func(index int64, delegation sdk.Delegation) (stop bool)) {
+	if ds[delegator.String()] {
+		fn(0, nil)
+	}
+}
+
+func TestCloseAccount(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	addr3 := sdk.Address([]byte("addr3"))
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper).WithDelegationSet(mockDelegationSet{addr3.String(): true})
+
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.setCoins(ctx, addr3, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	accNum := accountMapper.GetAccount(ctx, addr).GetAccountNumber()
+
+	// unknown accounts and accounts with delegations can't be closed
+	_, err := coinKeeper.CloseAccount(ctx, addr2, addr)
+	require.NotNil(t, err)
+	_, err = coinKeeper.CloseAccount(ctx, addr3, addr)
+	require.NotNil(t, err)
+	require.NotNil(t, accountMapper.GetAccount(ctx, addr3))
+
+	// the remaining coins go to the beneficiary
+	_, err = coinKeeper.CloseAccount(ctx, addr, addr2)
+	require.Nil(t, err)
+	require.Nil(t, accountMapper.GetAccount(ctx, addr))
+	require.Nil(t, accountMapper.GetAccountByNumber(ctx, accNum))
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+
+	// a recreated account gets a new account number
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.NotEqual(t, accNum, accountMapper.GetAccount(ctx, addr).GetAccountNumber())
+}
+
+func TestSupply(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	require.True(t, coinKeeper.GetSupply(ctx).IsZero())
+
+	// minting and burning adjusts the supply
+	_, _, err := coinKeeper.MintCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 100)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 100)}))
+	require.Nil(t, SupplyInvariant(ctx, coinKeeper))
+
+	_, _, err = coinKeeper.BurnCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 30)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 70)}))
+
+	// burning more than held fails and leaves the supply untouched
+	_, _, err = coinKeeper.BurnCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 71)})
+	require.NotNil(t, err)
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 70)}))
+
+	// sends don't change the supply
+	coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 20)})
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 70)}))
+	require.Nil(t, SupplyInvariant(ctx, coinKeeper))
+
+	// coins changed outside of the mint/burn paths break the invariant
+	coinKeeper.addCoins(ctx, addr2, sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.NotNil(t, SupplyInvariant(ctx, coinKeeper))
+}
+
+func TestModuleAccounts(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper).WithModuleAccounts(map[string][]string{
+		"minter": {auth.Minter},
+		"escrow": {auth.Burner, auth.Staking},
+	})
+
+	addr := sdk.Address([]byte("addr1"))
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.SetSupply(ctx, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+
+	// undeclared modules have no account
+	_, err := coinKeeper.GetModuleAccount(ctx, "unknown")
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoinsFromAccountToModule(ctx, addr, "unknown", sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.NotNil(t, err)
+
+	// module accounts are created on first use with their permissions
+	macc, err := coinKeeper.GetModuleAccount(ctx, "escrow")
+	require.Nil(t, err)
+	require.Equal(t, auth.NewModuleAddress("escrow"), macc.GetAddress())
+	require.True(t, macc.HasPermission(auth.Burner))
+	require.False(t, macc.HasPermission(auth.Minter))
+	require.NotNil(t, macc.SetPubKey(nil))
+
+	// minting requires the minter permission
+	_, err = coinKeeper.MintModuleCoins(ctx, "escrow", sdk.Coins{sdk.NewCoin("foocoin", 5)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.MintModuleCoins(ctx, "minter", sdk.Coins{sdk.NewCoin("foocoin", 5)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetModuleCoins(ctx, "minter").IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 5)}))
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 15)}))
+
+	// escrowing delegated coins requires the staking permission
+	_, err = coinKeeper.DelegateCoinsFromAccountToModule(ctx, addr, "minter", sdk.Coins{sdk.NewCoin("foocoin", 4)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.DelegateCoinsFromAccountToModule(ctx, addr, "escrow", sdk.Coins{sdk.NewCoin("foocoin", 4)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 6)}))
+	require.True(t, coinKeeper.GetModuleCoins(ctx, "escrow").IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 4)}))
+
+	_, err = coinKeeper.UndelegateCoinsFromModuleToAccount(ctx, "escrow", addr, sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetModuleCoins(ctx, "escrow").IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 3)}))
+
+	// burning requires the burner permission
+	_, err = coinKeeper.BurnModuleCoins(ctx, "minter", sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.BurnModuleCoins(ctx, "escrow", sdk.Coins{sdk.NewCoin("foocoin", 3)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetModuleCoins(ctx, "escrow").IsZero())
+	require.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 12)}))
+
+	// plain sends to and from module accounts need no permission
+	_, err = coinKeeper.SendCoinsFromModuleToAccount(ctx, "minter", addr, sdk.Coins{sdk.NewCoin("foocoin", 5)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 12)}))
+	require.Nil(t, SupplyInvariant(ctx, coinKeeper))
+}
+
+func TestSendEnabled(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)})
+
+	// denoms are enabled by default
+	require.True(t, coinKeeper.GetSendEnabled(ctx, "foocoin"))
+	coinKeeper.SetSendEnabled(ctx, "foocoin", false)
+	require.False(t, coinKeeper.GetSendEnabled(ctx, "foocoin"))
+	require.True(t, coinKeeper.GetSendEnabled(ctx, "barcoin"))
+
+	// sends including a disabled denom are rejected
+	inputs := []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("barcoin", 2), sdk.NewCoin("foocoin", 2)})}
+	outputs := []Output{NewOutput(addr2, sdk.Coins{sdk.NewCoin("barcoin", 2), sdk.NewCoin("foocoin", 2)})}
+	_, err := coinKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Equal(t, CodeSendDisabled, err.Code())
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsZero())
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Equal(t, CodeSendDisabled, err.Code())
+	_, err = NewSendKeeper(coinKeeper).SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Equal(t, CodeSendDisabled, err.Code())
+	_, err = coinKeeper.CloseAccount(ctx, addr, addr2)
+	require.Equal(t, CodeSendDisabled, err.Code())
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsZero())
+
+	// multi-output sends of enabled denoms go through
+	inputs = []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("barcoin", 3)})}
+	outputs = []Output{
+		NewOutputWithMemo(addr2, sdk.Coins{sdk.NewCoin("barcoin", 1)}, "first"),
+		NewOutputWithMemo(addr2, sdk.Coins{sdk.NewCoin("barcoin", 2)}, "second"),
+	}
+	_, err = coinKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 3)}))
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("barcoin", 1)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 4)}))
+
+	// the flags round trip through genesis
+	genesis := WriteGenesis(ctx, coinKeeper)
+	require.Equal(t, []SendEnabled{{"foocoin", false}}, genesis.SendEnabled)
+	coinKeeper.SetSendEnabled(ctx, "foocoin", true)
+	InitGenesis(ctx, coinKeeper, genesis)
+	require.False(t, coinKeeper.GetSendEnabled(ctx, "foocoin"))
+}
+
+// records the transfers it sees and refuses those of the refused denom
+type mockSendHooks struct {
+	refusedDenom string
+	before       *int
+	after        *[]Output
+}
+
+func (h mockSendHooks) BeforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error {
+	*h.before++
+	for _, in := range inputs {
+		if !in.Coins.AmountOf(h.refusedDenom).IsZero() {
+			return sdk.ErrUnauthorized("refused by hook")
+		}
+	}
+	return nil
+}
+
+func (h mockSendHooks) AfterSend(ctx sdk.Context, inputs []Input, outputs []Output) {
+	*h.after = append(*h.after, outputs...)
+}
+
+func TestSendHooks(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+
+	before := 0
+	after := []Output{}
+	hooks := mockSendHooks{"barcoin", &before, &after}
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper).
+		WithModuleAccounts(map[string][]string{"pool": nil}).
+		WithHooks(NewMultiSendHooks(hooks))
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)})
+
+	// hooks are called around sends
+	_, err := coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Nil(t, err)
+	require.Equal(t, 1, before)
+	require.Equal(t, []Output{NewOutput(addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})}, after)
+
+	// and around multi-sends
+	inputs := []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("foocoin", 3)})}
+	outputs := []Output{NewOutput(addr2, sdk.Coins{sdk.NewCoin("foocoin", 3)})}
+	_, err = coinKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Nil(t, err)
+	require.Equal(t, 2, before)
+	require.Equal(t, 2, len(after))
+
+	// and around transfers to module accounts
+	_, err = coinKeeper.SendCoinsFromAccountToModule(ctx, addr, "pool", sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.Nil(t, err)
+	require.Equal(t, 3, before)
+	require.Equal(t, 3, len(after))
+
+	// an error from the hooks aborts the transfer
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("barcoin", 2)})
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.Equal(t, 4, before)
+	require.Equal(t, 3, len(after))
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 4)}))
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 5)}))
+
+	// the coins of a closed account go through the hooks too
+	_, err = coinKeeper.CloseAccount(ctx, addr, addr2)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.Equal(t, 5, before)
+	require.NotNil(t, accountMapper.GetAccount(ctx, addr))
+	_, err = coinKeeper.CloseAccount(ctx, addr2, addr)
+	require.Nil(t, err)
+	require.Equal(t, 6, before)
+	require.Equal(t, 4, len(after))
+	require.Nil(t, accountMapper.GetAccount(ctx, addr2))
+
+	// transfers out of module accounts can't be refused
+	coinKeeper.setCoins(ctx, auth.NewModuleAddress("pool"), sdk.Coins{sdk.NewCoin("barcoin", 2), sdk.NewCoin("foocoin", 1)})
+	_, err = coinKeeper.SendCoinsFromModuleToAccount(ctx, "pool", addr, sdk.Coins{sdk.NewCoin("barcoin", 2)})
+	require.Nil(t, err)
+	require.Equal(t, 6, before)
+	require.Equal(t, 5, len(after))
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 12), sdk.NewCoin("foocoin", 9)}))
+}
+
+func TestBlockedAddrs(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	poolAddr := auth.NewModuleAddress("pool")
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper).
+		WithModuleAccounts(map[string][]string{"pool": nil}).
+		WithBlockedAddrs(poolAddr)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	require.True(t, coinKeeper.BlockedAddr(poolAddr))
+	require.False(t, coinKeeper.BlockedAddr(addr2))
+
+	// blocked addresses can't receive sends
+	_, err := coinKeeper.SendCoins(ctx, addr, poolAddr, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Equal(t, CodeBlockedAddr, err.Code())
+
+	// nor be among the outputs of a multi-send
+	inputs := []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("foocoin", 4)})}
+	outputs := []Output{
+		NewOutput(addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)}),
+		NewOutput(poolAddr, sdk.Coins{sdk.NewCoin("foocoin", 2)}),
+	}
+	_, err = coinKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Equal(t, CodeBlockedAddr, err.Code())
+
+	// nor be the beneficiary of a closed account
+	_, err = coinKeeper.CloseAccount(ctx, addr, poolAddr)
+	require.Equal(t, CodeBlockedAddr, err.Code())
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsZero())
+
+	// the module itself can still move coins into its account
+	_, err = coinKeeper.SendCoinsFromAccountToModule(ctx, addr, "pool", sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetModuleCoins(ctx, "pool").IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 2)}))
+
+	// blocking more addresses keeps the ones already blocked
+	withMore := coinKeeper.WithBlockedAddrs(addr2)
+	require.True(t, withMore.BlockedAddr(poolAddr))
+	require.True(t, withMore.BlockedAddr(addr2))
+	require.False(t, coinKeeper.BlockedAddr(addr2))
+}
+
+func TestSendKeeperChecks(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	poolAddr := auth.NewModuleAddress("pool")
+	before := 0
+	after := []Output{}
+	hooks := mockSendHooks{"barcoin", &before, &after}
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper).
+		WithHooks(NewMultiSendHooks(hooks)).
+		WithBlockedAddrs(poolAddr)
+	sendKeeper := NewSendKeeper(coinKeeper)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	coinKeeper.setCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)})
+
+	// the send keeper refuses the blocked addresses like the keeper
+	_, err := sendKeeper.SendCoins(ctx, addr, poolAddr, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Equal(t, CodeBlockedAddr, err.Code())
+	inputs := []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("foocoin", 2)})}
+	outputs := []Output{NewOutput(poolAddr, sdk.Coins{sdk.NewCoin("foocoin", 2)})}
+	_, err = sendKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Equal(t, CodeBlockedAddr, err.Code())
+	require.Equal(t, 0, before)
+
+	// and calls the hooks around its transfers
+	_, err = sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})
+	require.Nil(t, err)
+	outputs = []Output{NewOutput(addr2, sdk.Coins{sdk.NewCoin("foocoin", 2)})}
+	_, err = sendKeeper.InputOutputCoins(ctx, inputs, outputs)
+	require.Nil(t, err)
+	require.Equal(t, 2, before)
+	require.Equal(t, 2, len(after))
+
+	_, err = sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("barcoin", 2)})
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 4)}))
+}
+
+func TestParamChange(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+
+	require.Nil(t, coinKeeper.CheckParamChange(ParamSendEnabledKey("foocoin"), "false"))
+	require.NotNil(t, coinKeeper.CheckParamChange(ParamSendEnabledKey("foo coin"), "false"))
+	require.NotNil(t, coinKeeper.CheckParamChange(ParamSendEnabledKey("foocoin"), "no"))
+	require.NotNil(t, coinKeeper.CheckParamChange(ParamDenomMetadata, `{"base":"foocoin"}`))
+	require.NotNil(t, coinKeeper.CheckParamChange("Unknown", "false"))
+
+	require.Nil(t, coinKeeper.ApplyParamChange(ctx, ParamSendEnabledKey("foocoin"), "false"))
+	require.False(t, coinKeeper.GetSendEnabled(ctx, "foocoin"))
+	require.True(t, coinKeeper.GetSendEnabled(ctx, "barcoin"))
+
+	// the current params are listed with the keys and values changing them
+	values := make(map[string]string)
+	coinKeeper.IterateParams(ctx, func(key string, value string) (stop bool) {
+		values[key] = value
+		return false
+	})
+	require.Equal(t, map[string]string{ParamSendEnabledKey("foocoin"): "false"}, values)
+}
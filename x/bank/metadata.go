@@ -0,0 +1,186 @@
+package bank
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// same as the denominations accepted by sdk.ParseCoins
+	reDenom   = regexp.MustCompile(`^[[:alpha:]][[:alnum:]]{2,15}$`)
+	reDecimal = regexp.MustCompile(`^[[:digit:]]+(\.[[:digit:]]+)?$`)
+)
+
+// DenomUnit - a unit of a denom, worth 10^Exponent base units
+type DenomUnit struct {
+	Denom    string `json:"denom"`
+	Exponent uint32 `json:"exponent"`
+}
+
+// Metadata - display information of a denom, eg. 1 atom = 10^6 uatom
+type Metadata struct {
+	Description string      `json:"description"`
+	Base        string      `json:"base"`        // unit held in accounts, eg. uatom
+	Display     string      `json:"display"`     // unit shown to users, eg. atom
+	DenomUnits  []DenomUnit `json:"denom_units"` // the base unit first, by increasing exponent
+}
+
+// Validate checks that the units are well formed and include the base and display units
+func (m Metadata) Validate() error {
+	if !reDenom.MatchString(m.Base) {
+		return fmt.Errorf("invalid base denom %q", m.Base)
+	}
+	if len(m.DenomUnits) == 0 || m.DenomUnits[0].Denom != m.Base || m.DenomUnits[0].Exponent != 0 {
+		return fmt.Errorf("the first unit must be the base denom %s with exponent 0", m.Base)
+	}
+
+	seen := map[string]bool{}
+	for i, unit := range m.DenomUnits {
+		if !reDenom.MatchString(unit.Denom) {
+			return fmt.Errorf("invalid unit denom %q", unit.Denom)
+		}
+		if seen[unit.Denom] {
+			return fmt.Errorf("duplicate unit denom %s", unit.Denom)
+		}
+		seen[unit.Denom] = true
+		if i > 0 && unit.Exponent <= m.DenomUnits[i-1].Exponent {
+			return fmt.Errorf("unit exponents must be increasing, %s has %d", unit.Denom, unit.Exponent)
+		}
+	}
+	if !seen[m.Display] {
+		return fmt.Errorf("display denom %q is not one of the units", m.Display)
+	}
+	return nil
+}
+
+// GetUnit returns the unit of the given denom
+func (m Metadata) GetUnit(denom string) (DenomUnit, bool) {
+	for _, unit := range m.DenomUnits {
+		if unit.Denom == denom {
+			return unit, true
+		}
+	}
+	return DenomUnit{}, false
+}
+
+// ToBase converts a decimal amount of one of the units, eg. "1.5" atom, into
+// base units. Amounts smaller than a base unit are rejected.
+func (m Metadata) ToBase(amount string, denom string) (sdk.Int, error) {
+	unit, ok := m.GetUnit(denom)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("%s is not a unit of %s", denom, m.Base)
+	}
+
+	if !reDecimal.MatchString(amount) {
+		return sdk.Int{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	parts := strings.Split(amount, ".")
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = strings.TrimRight(parts[1], "0")
+	}
+	if len(fraction) > int(unit.Exponent) {
+		return sdk.Int{}, fmt.Errorf("%s%s is not a whole number of %s", amount, denom, m.Base)
+	}
+
+	digits := parts[0] + fraction + strings.Repeat("0", int(unit.Exponent)-len(fraction))
+	base, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	return sdk.NewIntFromBigInt(base), nil
+}
+
+// FormatDisplay formats an amount of base units in the display unit, eg. "1.5atom"
+func (m Metadata) FormatDisplay(amount sdk.Int) string {
+	unit, ok := m.GetUnit(m.Display)
+	if !ok || unit.Exponent == 0 {
+		return fmt.Sprintf("%v%s", amount, m.Display)
+	}
+
+	str := amount.BigInt().String()
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+	exp := int(unit.Exponent)
+	if len(str) <= exp {
+		str = strings.Repeat("0", exp-len(str)+1) + str
+	}
+	integer, fraction := str[:len(str)-exp], strings.TrimRight(str[len(str)-exp:], "0")
+
+	res := integer
+	if fraction != "" {
+		res += "." + fraction
+	}
+	if negative {
+		res = "-" + res
+	}
+	return res + m.Display
+}
+
+// GetDenomMetadata returns the metadata of a base denom or of any of its units
+func (keeper Keeper) GetDenomMetadata(ctx sdk.Context, denom string) (Metadata, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(DenomMetadataKey(denom))
+	if bz == nil {
+		base := store.Get(DenomUnitKey(denom))
+		if base == nil {
+			return Metadata{}, false
+		}
+		bz = store.Get(DenomMetadataKey(string(base)))
+	}
+
+	var metadata Metadata
+	keeper.cdc.MustUnmarshalBinary(bz, &metadata)
+	return metadata, true
+}
+
+// SetDenomMetadata validates and sets the metadata of a denom, indexing its units
+func (keeper Keeper) SetDenomMetadata(ctx sdk.Context, metadata Metadata) sdk.Error {
+	err := metadata.Validate()
+	if err != nil {
+		return ErrInvalidMetadata(DefaultCodespace, err.Error())
+	}
+
+	store := ctx.KVStore(keeper.storeKey)
+	for _, unit := range metadata.DenomUnits {
+		base := store.Get(DenomUnitKey(unit.Denom))
+		if base != nil && string(base) != metadata.Base {
+			return ErrInvalidMetadata(DefaultCodespace, fmt.Sprintf("%s is already a unit of %s", unit.Denom, base))
+		}
+		if unit.Denom != metadata.Base && store.Has(DenomMetadataKey(unit.Denom)) {
+			return ErrInvalidMetadata(DefaultCodespace, fmt.Sprintf("%s is already a base denom", unit.Denom))
+		}
+	}
+
+	// drop the index of the units no longer listed
+	old, found := keeper.GetDenomMetadata(ctx, metadata.Base)
+	if found && old.Base == metadata.Base {
+		for _, unit := range old.DenomUnits {
+			store.Delete(DenomUnitKey(unit.Denom))
+		}
+	}
+
+	for _, unit := range metadata.DenomUnits[1:] {
+		store.Set(DenomUnitKey(unit.Denom), []byte(metadata.Base))
+	}
+	store.Set(DenomMetadataKey(metadata.Base), keeper.cdc.MustMarshalBinary(metadata))
+	return nil
+}
+
+// IterateDenomMetadata iterates over the metadata of all denoms
+func (keeper Keeper) IterateDenomMetadata(ctx sdk.Context, process func(Metadata) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, DenomMetadataKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var metadata Metadata
+		keeper.cdc.MustUnmarshalBinary(iter.Value(), &metadata)
+		if process(metadata) {
+			return
+		}
+	}
+}
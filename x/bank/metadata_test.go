@@ -0,0 +1,119 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	wire "github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var atomMetadata = Metadata{
+	Description: "the staking token",
+	Base:        "uatom",
+	Display:     "atom",
+	DenomUnits: []DenomUnit{
+		{"uatom", 0},
+		{"matom", 3},
+		{"atom", 6},
+	},
+}
+
+func TestMetadataValidate(t *testing.T) {
+	require.Nil(t, atomMetadata.Validate())
+
+	cases := []func(m *Metadata){
+		func(m *Metadata) { m.Base = "" },                                               // no base
+		func(m *Metadata) { m.Display = "photon" },                                      // display isn't a unit
+		func(m *Metadata) { m.DenomUnits = m.DenomUnits[1:] },                           // no base unit
+		func(m *Metadata) { m.DenomUnits[0].Exponent = 1 },                              // base unit with an exponent
+		func(m *Metadata) { m.DenomUnits[2].Exponent = 3 },                              // exponents not increasing
+		func(m *Metadata) { m.DenomUnits = append(m.DenomUnits, DenomUnit{"atom", 9}) }, // duplicate unit
+		func(m *Metadata) { m.DenomUnits[1].Denom = "m" },                               // invalid denom
+	}
+	for i, tc := range cases {
+		m := atomMetadata
+		m.DenomUnits = append([]DenomUnit{}, atomMetadata.DenomUnits...)
+		tc(&m)
+		require.NotNil(t, m.Validate(), "%d", i)
+	}
+}
+
+func TestMetadataConversions(t *testing.T) {
+	cases := []struct {
+		amount string
+		denom  string
+		base   int64
+		valid  bool
+	}{
+		{"1", "atom", 1000000, true},
+		{"1.5", "atom", 1500000, true},
+		{"0.000001", "atom", 1, true},
+		{"1.50", "matom", 1500, true},
+		{"42", "uatom", 42, true},
+		{"0.0000001", "atom", 0, false}, // less than a base unit
+		{"0.5", "uatom", 0, false},      // less than a base unit
+		{"-1", "atom", 0, false},        // negative
+		{"1.", "atom", 0, false},        // invalid decimal
+		{"1", "photon", 0, false},       // unknown unit
+	}
+	for i, tc := range cases {
+		base, err := atomMetadata.ToBase(tc.amount, tc.denom)
+		if !tc.valid {
+			require.NotNil(t, err, "%d", i)
+			continue
+		}
+		require.Nil(t, err, "%d", i)
+		require.Equal(t, tc.base, base.Int64(), "%d", i)
+	}
+
+	require.Equal(t, "1atom", atomMetadata.FormatDisplay(sdk.NewInt(1000000)))
+	require.Equal(t, "1.5atom", atomMetadata.FormatDisplay(sdk.NewInt(1500000)))
+	require.Equal(t, "0.000042atom", atomMetadata.FormatDisplay(sdk.NewInt(42)))
+	require.Equal(t, "0atom", atomMetadata.FormatDisplay(sdk.NewInt(0)))
+}
+
+func TestKeeperDenomMetadata(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper)
+
+	_, found := coinKeeper.GetDenomMetadata(ctx, "uatom")
+	require.False(t, found)
+
+	require.Nil(t, coinKeeper.SetDenomMetadata(ctx, atomMetadata))
+
+	// the metadata is found from any of the units
+	for _, denom := range []string{"uatom", "matom", "atom"} {
+		metadata, found := coinKeeper.GetDenomMetadata(ctx, denom)
+		require.True(t, found, denom)
+		require.Equal(t, atomMetadata, metadata)
+	}
+
+	// units can't be claimed by another denom
+	photon := Metadata{Base: "uphoton", Display: "atom", DenomUnits: []DenomUnit{{"uphoton", 0}, {"atom", 6}}}
+	require.NotNil(t, coinKeeper.SetDenomMetadata(ctx, photon))
+	photon = Metadata{Base: "uphoton", Display: "uatom", DenomUnits: []DenomUnit{{"uphoton", 0}, {"uatom", 6}}}
+	require.NotNil(t, coinKeeper.SetDenomMetadata(ctx, photon))
+
+	// updating the metadata drops the units no longer listed
+	updated := atomMetadata
+	updated.DenomUnits = []DenomUnit{{"uatom", 0}, {"atom", 6}}
+	require.Nil(t, coinKeeper.SetDenomMetadata(ctx, updated))
+	_, found = coinKeeper.GetDenomMetadata(ctx, "matom")
+	require.False(t, found)
+
+	// the metadata round trips through genesis
+	genesis := WriteGenesis(ctx, coinKeeper)
+	require.Equal(t, []Metadata{updated}, genesis.DenomMetadata)
+}
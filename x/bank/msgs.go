@@ -2,10 +2,14 @@ package bank
 
 import (
 	"encoding/json"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// MaxOutputMemoCharacters is the maximum length of the memo of an Output
+const MaxOutputMemoCharacters = 100
+
 // MsgSend - high level transaction of the coin module
 type MsgSend struct {
 	Inputs  []Input  `json:"inputs"`
@@ -141,6 +145,59 @@ func (msg MsgIssue) GetSigners() []sdk.Address {
 	return []sdk.Address{msg.Banker}
 }
 
+//----------------------------------------
+// MsgCloseAccount
+
+// MsgCloseAccount - remove an account, sending its remaining coins to a beneficiary
+type MsgCloseAccount struct {
+	Address     sdk.Address `json:"address"`
+	Beneficiary sdk.Address `json:"beneficiary"`
+}
+
+var _ sdk.Msg = MsgCloseAccount{}
+
+// NewMsgCloseAccount - construct a msg closing the account at addr
+func NewMsgCloseAccount(addr, beneficiary sdk.Address) MsgCloseAccount {
+	return MsgCloseAccount{Address: addr, Beneficiary: beneficiary}
+}
+
+// Implements Msg.
+func (msg MsgCloseAccount) Type() string { return "bank" }
+
+// Implements Msg.
+func (msg MsgCloseAccount) ValidateBasic() sdk.Error {
+	if len(msg.Address) == 0 {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	if len(msg.Beneficiary) == 0 {
+		return sdk.ErrInvalidAddress(msg.Beneficiary.String())
+	}
+	if msg.Address.String() == msg.Beneficiary.String() {
+		return ErrCloseAccount(DefaultCodespace, "beneficiary must differ from the closed account")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgCloseAccount) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Address     string `json:"address"`
+		Beneficiary string `json:"beneficiary"`
+	}{
+		Address:     sdk.MustBech32ifyAcc(msg.Address),
+		Beneficiary: sdk.MustBech32ifyAcc(msg.Beneficiary),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgCloseAccount) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.Address}
+}
+
 //----------------------------------------
 // Input
 
@@ -191,10 +248,11 @@ func NewInput(addr sdk.Address, coins sdk.Coins) Input {
 //----------------------------------------
 // Output
 
-// Transaction Output
+// Transaction Output, with an optional memo for the recipient
 type Output struct {
 	Address sdk.Address `json:"address"`
 	Coins   sdk.Coins   `json:"coins"`
+	Memo    string      `json:"memo"`
 }
 
 // Return bytes to sign for Output
@@ -202,9 +260,11 @@ func (out Output) GetSignBytes() []byte {
 	bin, err := msgCdc.MarshalJSON(struct {
 		Address string    `json:"address"`
 		Coins   sdk.Coins `json:"coins"`
+		Memo    string    `json:"memo,omitempty"`
 	}{
 		Address: sdk.MustBech32ifyAcc(out.Address),
 		Coins:   out.Coins,
+		Memo:    out.Memo,
 	})
 	if err != nil {
 		panic(err)
@@ -223,6 +283,9 @@ func (out Output) ValidateBasic() sdk.Error {
 	if !out.Coins.IsPositive() {
 		return sdk.ErrInvalidCoins(out.Coins.String())
 	}
+	if len(out.Memo) > MaxOutputMemoCharacters {
+		return ErrInvalidOutput(DefaultCodespace, fmt.Sprintf("memo is longer than %d characters", MaxOutputMemoCharacters))
+	}
 	return nil
 }
 
@@ -234,3 +297,10 @@ func NewOutput(addr sdk.Address, coins sdk.Coins) Output {
 	}
 	return output
 }
+
+// NewOutputWithMemo - create a transaction output with a memo for the recipient
+func NewOutputWithMemo(addr sdk.Address, coins sdk.Coins, memo string) Output {
+	output := NewOutput(addr, coins)
+	output.Memo = memo
+	return output
+}
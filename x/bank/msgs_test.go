@@ -2,6 +2,7 @@ package bank
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -89,6 +90,7 @@ func TestOutputValidation(t *testing.T) {
 		{true, NewOutput(addr1, someCoins)},
 		{true, NewOutput(addr2, someCoins)},
 		{true, NewOutput(addr2, multiCoins)},
+		{true, NewOutputWithMemo(addr1, someCoins, "invoice 42")},
 
 		{false, NewOutput(emptyAddr, someCoins)},  // empty address
 		{false, NewOutput(addr1, emptyCoins)},     // invalid coins
@@ -97,6 +99,8 @@ func TestOutputValidation(t *testing.T) {
 		{false, NewOutput(addr1, minusCoins)},     // negative coins
 		{false, NewOutput(addr1, someMinusCoins)}, // negative coins
 		{false, NewOutput(addr1, unsortedCoins)},  // unsorted coins
+
+		{false, NewOutputWithMemo(addr1, someCoins, strings.Repeat("a", MaxOutputMemoCharacters+1))}, // memo too long
 	}
 
 	for i, tc := range cases {
@@ -189,6 +193,13 @@ func TestMsgSendGetSignBytes(t *testing.T) {
 
 	expected := `{"inputs":[{"address":"cosmosaccaddr1d9h8qat5e4ehc5","coins":[{"denom":"atom","amount":"10"}]}],"outputs":[{"address":"cosmosaccaddr1da6hgur4wse3jx32","coins":[{"denom":"atom","amount":"10"}]}]}`
 	require.Equal(t, expected, string(res))
+
+	// output memos are only signed when set
+	msg.Outputs = []Output{NewOutputWithMemo(addr2, coins, "invoice 42")}
+	res = msg.GetSignBytes()
+
+	expected = `{"inputs":[{"address":"cosmosaccaddr1d9h8qat5e4ehc5","coins":[{"denom":"atom","amount":"10"}]}],"outputs":[{"address":"cosmosaccaddr1da6hgur4wse3jx32","coins":[{"denom":"atom","amount":"10"}],"memo":"invoice 42"}]}`
+	require.Equal(t, expected, string(res))
 }
 
 func TestMsgSendGetSigners(t *testing.T) {
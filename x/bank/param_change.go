@@ -0,0 +1,103 @@
+package bank
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keys of the bank params which can be changed by governance, the send
+// enabled flag of a denom is set by the key SendEnabled/<denom>
+const (
+	ParamSendEnabled   = "SendEnabled"
+	ParamDenomMetadata = "DenomMetadata"
+)
+
+// ParamSendEnabledKey returns the key of the send enabled flag of a denom
+func ParamSendEnabledKey(denom string) string {
+	return ParamSendEnabled + "/" + denom
+}
+
+// decode a JSON value of a bank param, the result is a SendEnabled or a Metadata
+func decodeParamChange(key string, value string) (interface{}, sdk.Error) {
+	switch {
+	case strings.HasPrefix(key, ParamSendEnabled+"/"):
+		denom := strings.TrimPrefix(key, ParamSendEnabled+"/")
+		if !reDenom.MatchString(denom) {
+			return nil, params.ErrInvalidParamValue(params.DefaultCodespace, "bank", key, fmt.Sprintf("invalid denom %q", denom))
+		}
+		var enabled bool
+		err := msgCdc.UnmarshalJSON([]byte(value), &enabled)
+		if err != nil {
+			return nil, params.ErrInvalidParamValue(params.DefaultCodespace, "bank", key, err.Error())
+		}
+		return SendEnabled{denom, enabled}, nil
+
+	case key == ParamDenomMetadata:
+		var metadata Metadata
+		err := msgCdc.UnmarshalJSON([]byte(value), &metadata)
+		if err != nil {
+			return nil, params.ErrInvalidParamValue(params.DefaultCodespace, "bank", key, err.Error())
+		}
+		err = metadata.Validate()
+		if err != nil {
+			return nil, params.ErrInvalidParamValue(params.DefaultCodespace, "bank", key, err.Error())
+		}
+		return metadata, nil
+
+	default:
+		return nil, params.ErrUnknownParam(params.DefaultCodespace, "bank", key)
+	}
+}
+
+// encode the value of a bank param as in a change
+func encodeParamValue(value interface{}) string {
+	bz, err := msgCdc.MarshalJSON(value)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// IterateParams iterates over the current send enabled flags and denom
+// metadata with the keys and JSON encoded values used to change them
+func (keeper Keeper) IterateParams(ctx sdk.Context, process func(key string, value string) (stop bool)) {
+	stopped := false
+	keeper.IterateSendEnabled(ctx, func(denom string, enabled bool) (stop bool) {
+		stopped = process(ParamSendEnabledKey(denom), encodeParamValue(enabled))
+		return stopped
+	})
+	if stopped {
+		return
+	}
+	keeper.IterateDenomMetadata(ctx, func(metadata Metadata) (stop bool) {
+		return process(ParamDenomMetadata, encodeParamValue(metadata))
+	})
+}
+
+// CheckParamChange checks a change of the send enabled flag of a denom or of
+// the metadata of a denom, proposed to governance
+func (keeper Keeper) CheckParamChange(key string, value string) sdk.Error {
+	_, err := decodeParamChange(key, value)
+	return err
+}
+
+// ApplyParamChange applies a change of the send enabled flag of a denom or of
+// the metadata of a denom
+func (keeper Keeper) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	decoded, err := decodeParamChange(key, value)
+	if err != nil {
+		return err
+	}
+	switch decoded := decoded.(type) {
+	case SendEnabled:
+		keeper.SetSendEnabled(ctx, decoded.Denom, decoded.Enabled)
+		return nil
+	case Metadata:
+		return keeper.SetDenomMetadata(ctx, decoded)
+	default:
+		panic("unexpected bank param")
+	}
+}
@@ -0,0 +1,83 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// AllocateTokens distributes the fees collected and the inflation provisions
+// of the previous block. The community pool takes its tax, the proposer its
+// reward growing with the fraction of the voting power whose precommits it
+// included, and the rest is split among the bonded validators by power.
+func (k Keeper) AllocateTokens(ctx sdk.Context, precommitFraction sdk.Rat, proposer sdk.Address) {
+
+	// move the collected fees and the provisions to the distribution account
+	fees := k.ck.GetModuleCoins(ctx, auth.FeeCollectorName)
+	if !fees.IsZero() {
+		_, err := k.ck.SendCoinsFromModuleToModule(ctx, auth.FeeCollectorName, ModuleName, fees)
+		if err != nil {
+			panic(err)
+		}
+	}
+	k.fck.ClearCollectedFees(ctx)
+	provisions := k.sk.WithdrawProvisions(ctx, ModuleName)
+
+	total := NewDecCoins(fees.Plus(provisions))
+	if total.IsZero() {
+		return
+	}
+
+	params := k.GetParams(ctx)
+	feePool := k.GetFeePool(ctx)
+	remaining := total
+
+	communityTax := total.MulRat(params.CommunityTax)
+	remaining = remaining.Minus(communityTax)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(communityTax)
+
+	// pay the proposer of the previous block
+	if proposer != nil {
+		validator, found := k.sk.GetValidator(ctx, proposer)
+		if found && validator.Status() == sdk.Bonded {
+			rate := params.BaseProposerReward.Add(params.BonusProposerReward.Mul(precommitFraction))
+			reward := total.MulRat(rate)
+			remaining = remaining.Minus(reward)
+			unallocated := k.allocateToValidator(ctx, validator, reward)
+			feePool.CommunityPool = feePool.CommunityPool.Plus(unallocated)
+		}
+	}
+
+	// split the rest by voting power
+	totalPower := k.sk.TotalPower(ctx)
+	if !totalPower.IsZero() {
+		distributed := DecCoins{}
+		for _, validator := range k.sk.GetValidatorsBonded(ctx) {
+			reward := remaining.MulRat(validator.GetPower().Quo(totalPower))
+			distributed = distributed.Plus(reward)
+			unallocated := k.allocateToValidator(ctx, validator, reward)
+			feePool.CommunityPool = feePool.CommunityPool.Plus(unallocated)
+		}
+		remaining = remaining.Minus(distributed)
+	}
+
+	// whatever couldn't be split goes to the community
+	feePool.CommunityPool = feePool.CommunityPool.Plus(remaining)
+	k.SetFeePool(ctx, feePool)
+}
+
+// credit a validator with a reward, the validator keeps its commission and
+// the rest accrues to the delegator shares. Returns the reward which could
+// not be credited as the validator has no delegator shares.
+func (k Keeper) allocateToValidator(ctx sdk.Context, validator stake.Validator, reward DecCoins) (unallocated DecCoins) {
+	if validator.DelegatorShares.IsZero() {
+		return reward
+	}
+
+	info := k.GetValidatorDistInfo(ctx, validator.Owner)
+	commission := reward.MulRat(validator.Commission)
+	info.Commission = info.Commission.Plus(commission)
+	info.RewardsPerShare = info.RewardsPerShare.Plus(reward.Minus(commission).QuoRat(validator.DelegatorShares))
+	k.SetValidatorDistInfo(ctx, info)
+	return DecCoins{}
+}
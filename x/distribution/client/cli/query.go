@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+// get the command to query the community pool
+func GetCmdQueryCommunityPool(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "community-pool",
+		Args:  cobra.NoArgs,
+		Short: "query the coins of the community pool",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(distribution.FeePoolKey, storeName)
+			if err != nil {
+				return err
+			}
+
+			feePool := distribution.InitialFeePool()
+			if len(res) != 0 {
+				cdc.MustUnmarshalBinary(res, &feePool)
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, feePool.CommunityPool)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+// create withdraw rewards command
+func GetCmdWithdrawDelegatorReward(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-rewards [validator-addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "withdraw the rewards of a delegation to a validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			validatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			delegatorAddr, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawDelegatorReward(delegatorAddr, validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
+
+// create withdraw commission command
+func GetCmdWithdrawValidatorCommission(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-commission",
+		Args:  cobra.NoArgs,
+		Short: "withdraw the commission earned by the validator of the from key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			validatorAddr, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawValidatorCommission(validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/distribution/community_pool",
+		communityPoolHandlerFn(ctx, "distr", cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the community pool
+func communityPoolHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := ctx.QueryStore(distribution.FeePoolKey, storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query the community pool. Error: %s", err.Error())))
+			return
+		}
+
+		feePool := distribution.InitialFeePool()
+		if len(res) != 0 {
+			err = cdc.UnmarshalBinary(res, &feePool)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("couldn't decode the community pool. Error: %s", err.Error())))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(feePool.CommunityPool)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+func registerTxRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
+	r.HandleFunc(
+		"/distribution/withdraw_rewards",
+		withdrawRewardsRequestHandlerFn(cdc, kb, ctx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/distribution/withdraw_commission",
+		withdrawCommissionRequestHandlerFn(cdc, kb, ctx),
+	).Methods("POST")
+}
+
+// Withdraw TX body, the rewards are withdrawn for the delegator of the
+// local account, the commission for the validator owned by it
+type WithdrawBody struct {
+	LocalAccountName string `json:"name"`
+	Password         string `json:"password"`
+	ChainID          string `json:"chain_id"`
+	AccountNumber    int64  `json:"account_number"`
+	Sequence         int64  `json:"sequence"`
+	TimeoutHeight    int64  `json:"timeout_height"`
+	Gas              int64  `json:"gas"`
+	ValidatorAddr    string `json:"validator_addr"`
+}
+
+func withdrawRewardsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m, delegatorAddr, ok := readWithdrawBody(w, r, kb)
+		if !ok {
+			return
+		}
+
+		validatorAddr, err := sdk.GetAccAddressBech32(m.ValidatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error())))
+			return
+		}
+
+		msg := distribution.NewMsgWithdrawDelegatorReward(delegatorAddr, validatorAddr)
+		signAndBroadcast(w, cdc, ctx, m, msg)
+	}
+}
+
+func withdrawCommissionRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m, validatorAddr, ok := readWithdrawBody(w, r, kb)
+		if !ok {
+			return
+		}
+
+		msg := distribution.NewMsgWithdrawValidatorCommission(validatorAddr)
+		signAndBroadcast(w, cdc, ctx, m, msg)
+	}
+}
+
+// read the request body and the address of its local account
+func readWithdrawBody(w http.ResponseWriter, r *http.Request, kb keys.Keybase) (m WithdrawBody, addr sdk.Address, ok bool) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	err = json.Unmarshal(body, &m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	info, err := kb.Get(m.LocalAccountName)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	return m, info.GetPubKey().Address(), true
+}
+
+func signAndBroadcast(w http.ResponseWriter, cdc *wire.Codec, ctx context.CoreContext, m WithdrawBody, msg sdk.Msg) {
+	ctx = ctx.WithGas(m.Gas)
+	ctx = ctx.WithChainID(m.ChainID)
+	ctx = ctx.WithAccountNumber(m.AccountNumber)
+	ctx = ctx.WithSequence(m.Sequence)
+	ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
+
+	txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	res, err := ctx.BroadcastTx(txBytes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write(output)
+}
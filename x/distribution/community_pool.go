@@ -0,0 +1,21 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DistributeFromFeePool pays coins of the community pool to a recipient, as
+// decided by a passed community pool spend proposal
+func (k Keeper) DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, recipient sdk.Address) sdk.Error {
+	feePool := k.GetFeePool(ctx)
+	for _, coin := range amount {
+		if feePool.CommunityPool.AmountOf(coin.Denom).LT(sdk.NewRatFromInt(coin.Amount)) {
+			return ErrInsufficientCommunityPool(k.codespace, amount)
+		}
+	}
+	feePool.CommunityPool = feePool.CommunityPool.Minus(NewDecCoins(amount))
+	k.SetFeePool(ctx, feePool)
+
+	_, err := k.ck.SendCoinsFromModuleToAccount(ctx, ModuleName, recipient, amount)
+	return err
+}
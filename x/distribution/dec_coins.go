@@ -0,0 +1,146 @@
+package distribution
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// precision of the decimal amounts accounted by the module, amounts are
+// truncated to multiples of 1/precision so that the rationals don't grow
+const precision = 1000000000000000000
+
+// DecCoin - coin with a fractional amount, used to account the rewards which
+// have not been withdrawn yet
+type DecCoin struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Rat `json:"amount"`
+}
+
+// DecCoins - set of decimal coins, sorted by denom
+type DecCoins []DecCoin
+
+// NewDecCoins converts coins to decimal coins
+func NewDecCoins(coins sdk.Coins) DecCoins {
+	decCoins := DecCoins{}
+	for _, coin := range coins {
+		if coin.Amount.IsZero() {
+			continue
+		}
+		decCoins = append(decCoins, DecCoin{coin.Denom, sdk.NewRatFromInt(coin.Amount)})
+	}
+	return decCoins
+}
+
+// Plus combines two sets of decimal coins, dropping the zero amounts
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := DecCoins{}
+	indexA, indexB := 0, 0
+	lenA, lenB := len(coins), len(coinsB)
+	for {
+		if indexA == lenA {
+			if indexB == lenB {
+				return sum
+			}
+			return append(sum, coinsB[indexB:]...)
+		} else if indexB == lenB {
+			return append(sum, coins[indexA:]...)
+		}
+		coinA, coinB := coins[indexA], coinsB[indexB]
+		switch strings.Compare(coinA.Denom, coinB.Denom) {
+		case -1:
+			sum = append(sum, coinA)
+			indexA++
+		case 0:
+			amount := coinA.Amount.Add(coinB.Amount)
+			if !amount.IsZero() {
+				sum = append(sum, DecCoin{coinA.Denom, amount})
+			}
+			indexA++
+			indexB++
+		case 1:
+			sum = append(sum, coinB)
+			indexB++
+		}
+	}
+}
+
+// Minus subtracts a set of decimal coins from another
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	negative := make(DecCoins, len(coinsB))
+	for i, coin := range coinsB {
+		negative[i] = DecCoin{coin.Denom, sdk.ZeroRat().Sub(coin.Amount)}
+	}
+	return coins.Plus(negative)
+}
+
+// MulRat multiplies all the amounts by a rational, truncating the results
+func (coins DecCoins) MulRat(r sdk.Rat) DecCoins {
+	res := DecCoins{}
+	for _, coin := range coins {
+		amount := truncate(coin.Amount.Mul(r))
+		if !amount.IsZero() {
+			res = append(res, DecCoin{coin.Denom, amount})
+		}
+	}
+	return res
+}
+
+// QuoRat divides all the amounts by a rational, truncating the results
+func (coins DecCoins) QuoRat(r sdk.Rat) DecCoins {
+	res := DecCoins{}
+	for _, coin := range coins {
+		amount := truncate(coin.Amount.Quo(r))
+		if !amount.IsZero() {
+			res = append(res, DecCoin{coin.Denom, amount})
+		}
+	}
+	return res
+}
+
+// AmountOf returns the amount of a denom
+func (coins DecCoins) AmountOf(denom string) sdk.Rat {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return sdk.ZeroRat()
+}
+
+// IsZero returns true if there are no coins or all amounts are zero
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// TruncateDecimal splits the decimal coins into their whole coins and the
+// fractional change left over
+func (coins DecCoins) TruncateDecimal() (sdk.Coins, DecCoins) {
+	whole := sdk.Coins{}
+	change := DecCoins{}
+	for _, coin := range coins {
+		amount := new(big.Int).Quo(coin.Amount.Num().BigInt(), coin.Amount.Denom().BigInt())
+		if amount.Sign() != 0 {
+			whole = append(whole, sdk.Coin{coin.Denom, sdk.NewIntFromBigInt(amount)})
+		}
+		rest := coin.Amount.Sub(sdk.NewRatFromBigInt(amount))
+		if !rest.IsZero() {
+			change = append(change, DecCoin{coin.Denom, rest})
+		}
+	}
+	return whole, change
+}
+
+// truncate a rational to a multiple of 1/precision
+func truncate(r sdk.Rat) sdk.Rat {
+	prec := big.NewInt(precision)
+	num := new(big.Int).Mul(r.Num().BigInt(), prec)
+	num.Quo(num, r.Denom().BigInt())
+	return sdk.NewRatFromBigInt(num, prec)
+}
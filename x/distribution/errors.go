@@ -0,0 +1,40 @@
+//nolint
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default distribution codespace
+	DefaultCodespace sdk.CodespaceType = 12
+
+	CodeInvalidInput     CodeType = 101
+	CodeInvalidValidator CodeType = 102
+	CodeInvalidDelegator CodeType = 103
+	CodeInsufficientPool CodeType = 104
+)
+
+func ErrInvalidParams(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "invalid distribution params: "+msg)
+}
+func ErrBadValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator address is nil")
+}
+func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator does not exist for that address")
+}
+func ErrBadDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegator, "delegator address is nil")
+}
+func ErrNoDelegationForAddress(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegator, "delegator does not contain this delegation")
+}
+func ErrInsufficientCommunityPool(codespace sdk.CodespaceType, amount sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientPool, fmt.Sprintf("community pool does not hold %v", amount))
+}
@@ -0,0 +1,58 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all distribution state that must be provided at genesis
+type GenesisState struct {
+	Params              Params               `json:"params"`
+	FeePool             FeePool              `json:"fee_pool"`
+	ValidatorDistInfos  []ValidatorDistInfo  `json:"validator_dist_infos"`
+	DelegationDistInfos []DelegationDistInfo `json:"delegation_dist_infos"`
+	PreviousProposer    sdk.Address          `json:"previous_proposer"`
+}
+
+func NewGenesisState(params Params, feePool FeePool) GenesisState {
+	return GenesisState{
+		Params:  params,
+		FeePool: feePool,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:  DefaultParams(),
+		FeePool: InitialFeePool(),
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	err := data.Params.Validate()
+	if err != nil {
+		// TODO: Handle this with #870
+		panic(err)
+	}
+	k.SetParams(ctx, data.Params)
+	k.SetFeePool(ctx, data.FeePool)
+	for _, info := range data.ValidatorDistInfos {
+		k.SetValidatorDistInfo(ctx, info)
+	}
+	for _, info := range data.DelegationDistInfos {
+		k.SetDelegationDistInfo(ctx, info)
+	}
+	k.SetPreviousProposer(ctx, data.PreviousProposer)
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		Params:              k.GetParams(ctx),
+		FeePool:             k.GetFeePool(ctx),
+		ValidatorDistInfos:  k.GetAllValidatorDistInfos(ctx),
+		DelegationDistInfos: k.GetAllDelegationDistInfos(ctx),
+		PreviousProposer:    k.GetPreviousProposer(ctx),
+	}
+}
@@ -0,0 +1,50 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgWithdrawDelegatorReward:
+			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
+		case MsgWithdrawValidatorCommission:
+			return handleMsgWithdrawValidatorCommission(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
+		}
+	}
+}
+
+func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg MsgWithdrawDelegatorReward, k Keeper) sdk.Result {
+	_, err := k.WithdrawDelegatorReward(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"action", []byte("withdrawDelegatorReward"),
+		"delegator", []byte(msg.DelegatorAddr.String()),
+		"validator", []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg MsgWithdrawValidatorCommission, k Keeper) sdk.Result {
+	_, err := k.WithdrawValidatorCommission(ctx, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"action", []byte("withdrawValidatorCommission"),
+		"validator", []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
@@ -0,0 +1,47 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks - staking hooks keeping the distribution infos in step with the
+// delegations: the rewards of a delegation are withdrawn before its shares
+// change, as they were accrued at the previous number of shares
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// withdraw the rewards accrued at the current shares
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.Address, valAddr sdk.Address) {
+	_, err := h.k.WithdrawDelegatorReward(ctx, delAddr, valAddr)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// start accruing the rewards of the new shares from now on
+func (h Hooks) AfterDelegationSharesModified(ctx sdk.Context, delAddr sdk.Address, valAddr sdk.Address) {
+	if _, found := h.k.sk.GetDelegation(ctx, delAddr, valAddr); !found {
+		h.k.RemoveDelegationDistInfo(ctx, delAddr, valAddr)
+		return
+	}
+	info := h.k.GetDelegationDistInfo(ctx, delAddr, valAddr)
+	info.RewardsPerShare = h.k.GetValidatorDistInfo(ctx, valAddr).RewardsPerShare
+	h.k.SetDelegationDistInfo(ctx, info)
+}
+
+// pay the validator its remaining commission
+func (h Hooks) BeforeValidatorRemoved(ctx sdk.Context, valAddr sdk.Address) {
+	_, err := h.k.WithdrawValidatorCommission(ctx, valAddr)
+	if err != nil {
+		panic(err)
+	}
+	h.k.RemoveValidatorDistInfo(ctx, valAddr)
+}
@@ -0,0 +1,161 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// Keeper of the distribution store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+	ck       bank.Keeper
+	sk       stake.Keeper
+	fck      auth.FeeCollectionKeeper
+
+	// the distribution params
+	paramspace params.Subspace
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a distribution keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, sk stake.Keeper,
+	fck auth.FeeCollectionKeeper, paramspace params.Subspace, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:   key,
+		cdc:        cdc,
+		ck:         ck,
+		sk:         sk,
+		fck:        fck,
+		paramspace: paramspace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+	return keeper
+}
+
+//_________________________________________________________________________
+
+// get the global fee pool
+func (k Keeper) GetFeePool(ctx sdk.Context) (feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(FeePoolKey)
+	if bz == nil {
+		panic("stored fee pool should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(bz, &feePool)
+	return
+}
+
+// set the global fee pool
+func (k Keeper) SetFeePool(ctx sdk.Context, feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(feePool)
+	store.Set(FeePoolKey, bz)
+}
+
+// get the owner of the validator which proposed the previous block, nil if unknown
+func (k Keeper) GetPreviousProposer(ctx sdk.Context) sdk.Address {
+	store := ctx.KVStore(k.storeKey)
+	return store.Get(ProposerKey)
+}
+
+// set the owner of the validator which proposed the previous block
+func (k Keeper) SetPreviousProposer(ctx sdk.Context, proposer sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	if proposer == nil {
+		store.Delete(ProposerKey)
+		return
+	}
+	store.Set(ProposerKey, proposer)
+}
+
+//_________________________________________________________________________
+
+// get the distribution info of a validator, empty if none has been recorded
+func (k Keeper) GetValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) (info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorDistInfoKey(validatorAddr))
+	if bz == nil {
+		return ValidatorDistInfo{
+			ValidatorAddr:   validatorAddr,
+			Commission:      DecCoins{},
+			RewardsPerShare: DecCoins{},
+		}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return
+}
+
+// set the distribution info of a validator
+func (k Keeper) SetValidatorDistInfo(ctx sdk.Context, info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(info)
+	store.Set(GetValidatorDistInfoKey(info.ValidatorAddr), bz)
+}
+
+// remove the distribution info of a validator
+func (k Keeper) RemoveValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorDistInfoKey(validatorAddr))
+}
+
+// get all the validator distribution infos
+func (k Keeper) GetAllValidatorDistInfos(ctx sdk.Context) (infos []ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info ValidatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		infos = append(infos, info)
+	}
+	iterator.Close()
+	return infos
+}
+
+// get the distribution info of a delegation, empty if none has been recorded
+func (k Keeper) GetDelegationDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (info DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDelegationDistInfoKey(delegatorAddr, validatorAddr))
+	if bz == nil {
+		return DelegationDistInfo{
+			DelegatorAddr:   delegatorAddr,
+			ValidatorAddr:   validatorAddr,
+			RewardsPerShare: DecCoins{},
+		}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return
+}
+
+// set the distribution info of a delegation
+func (k Keeper) SetDelegationDistInfo(ctx sdk.Context, info DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(info)
+	store.Set(GetDelegationDistInfoKey(info.DelegatorAddr, info.ValidatorAddr), bz)
+}
+
+// remove the distribution info of a delegation
+func (k Keeper) RemoveDelegationDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegationDistInfoKey(delegatorAddr, validatorAddr))
+}
+
+// get all the delegation distribution infos
+func (k Keeper) GetAllDelegationDistInfos(ctx sdk.Context) (infos []DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegationDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info DelegationDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		infos = append(infos, info)
+	}
+	iterator.Close()
+	return infos
+}
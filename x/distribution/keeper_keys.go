@@ -0,0 +1,29 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+var (
+	// Keys for store prefixes
+	FeePoolKey            = []byte{0x01} // key for the global fee pool
+	ProposerKey           = []byte{0x02} // key for the proposer of the previous block
+	ValidatorDistInfoKey  = []byte{0x03} // prefix for each key to a validator distribution info
+	DelegationDistInfoKey = []byte{0x04} // prefix for each key to a delegation distribution info
+)
+
+// get the key for the distribution info of a validator
+func GetValidatorDistInfoKey(validatorAddr sdk.Address) []byte {
+	return append(ValidatorDistInfoKey, validatorAddr.Bytes()...)
+}
+
+// get the key for the distribution info of a delegation
+func GetDelegationDistInfoKey(delegatorAddr, validatorAddr sdk.Address) []byte {
+	return append(GetDelegationDistInfosKey(delegatorAddr), validatorAddr.Bytes()...)
+}
+
+// get the prefix for the distribution infos of all the delegations of a delegator
+func GetDelegationDistInfosKey(delegatorAddr sdk.Address) []byte {
+	return append(DelegationDistInfoKey, delegatorAddr.Bytes()...)
+}
@@ -0,0 +1,175 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// collect fees in the fee collector account as the ante handler does
+func collectFees(t *testing.T, ctx sdk.Context, keeper Keeper, amt int64) {
+	_, _, err := keeper.ck.MintCoins(ctx, auth.NewModuleAddress(auth.FeeCollectorName), sdk.Coins{sdk.NewCoin("steak", amt)})
+	require.Nil(t, err)
+}
+
+func TestAllocateTokens(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	amt := sdk.NewInt(100)
+
+	// validator 0 charges a 10% commission, validator 1 none
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], amt, sdk.NewRat(1, 10)))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], amt, sdk.ZeroRat()))
+	require.True(t, got.IsOK(), "%v", got)
+	stake.EndBlocker(ctx, sk)
+
+	// nothing to allocate
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[0])
+	require.True(t, keeper.GetFeePool(ctx).CommunityPool.IsZero())
+
+	// 2% community tax, 1% + 4% proposer reward, the rest split by power
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[0])
+	require.True(t, ck.GetModuleCoins(ctx, auth.FeeCollectorName).IsZero())
+	require.True(t, ck.GetModuleCoins(ctx, ModuleName).IsEqual(sdk.Coins{sdk.NewCoin("steak", 1000)}))
+	require.True(sdk.RatEq(t, sdk.NewRat(20), keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+
+	// validator 0 earned 50 + 465, of which it keeps 10%
+	info0 := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(sdk.RatEq(t, sdk.NewRat(515, 10), info0.Commission.AmountOf("steak")))
+	require.True(sdk.RatEq(t, sdk.NewRat(4635, 1000), info0.RewardsPerShare.AmountOf("steak")))
+	info1 := keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, info1.Commission.IsZero())
+	require.True(sdk.RatEq(t, sdk.NewRat(465, 100), info1.RewardsPerShare.AmountOf("steak")))
+
+	// half the precommits, no proposer bonus beyond 3%
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.NewRat(1, 2), addrs[1])
+	info1 = keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(sdk.RatEq(t, sdk.NewRat(465+30+475, 100), info1.RewardsPerShare.AmountOf("steak")))
+
+	// an unbonded proposer gets no reward, which is split among the validators
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[2])
+	info1 = keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(sdk.RatEq(t, sdk.NewRat(465+30+475+490, 100), info1.RewardsPerShare.AmountOf("steak")))
+	require.True(sdk.RatEq(t, sdk.NewRat(60), keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	handler := NewHandler(keeper)
+	amt := sdk.NewInt(100)
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], amt, sdk.NewRat(1, 10)))
+	require.True(t, got.IsOK(), "%v", got)
+	stake.EndBlocker(ctx, sk)
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[0])
+
+	// the validator earned 980, of which 98 commission
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[0], addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).AddRaw(882), ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+	got = handler(ctx, NewMsgWithdrawValidatorCommission(addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).AddRaw(980), ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+
+	// nothing left to withdraw
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[0], addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).AddRaw(980), ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+
+	// withdrawing without a delegation or a validator fails
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[1], addrs[0]))
+	require.False(t, got.IsOK())
+	got = handler(ctx, NewMsgWithdrawValidatorCommission(addrs[1]))
+	require.False(t, got.IsOK())
+
+	// a new delegation only earns the rewards allocated after it
+	got = stakeHandler(ctx, newTestMsgDelegate(addrs[1], addrs[0], amt))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[1], addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt), ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[0])
+
+	// the rewards are withdrawn before the delegation shares change
+	got = stakeHandler(ctx, newTestMsgDelegate(addrs[1], addrs[0], amt))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).Sub(amt).AddRaw(441), ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[1], addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).Sub(amt).AddRaw(441), ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+
+	// the distribution info of a delegation is removed with it
+	got = stakeHandler(ctx, stake.NewMsgBeginUnbonding(addrs[1], addrs[0], sdk.NewRat(200)))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, 1, len(keeper.GetAllDelegationDistInfos(ctx)))
+}
+
+func TestWithdrawRewardsChange(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	handler := NewHandler(keeper)
+	amt := sdk.NewInt(100)
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], amt, sdk.NewRat(1, 10)))
+	require.True(t, got.IsOK(), "%v", got)
+	stake.EndBlocker(ctx, sk)
+	collectFees(t, ctx, keeper, 1001)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), addrs[0])
+	require.True(sdk.RatEq(t, sdk.NewRat(2002, 100), keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+
+	// the delegator is owed 882.882, the change funds the community pool
+	got = handler(ctx, NewMsgWithdrawDelegatorReward(addrs[0], addrs[0]))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins.Sub(amt).AddRaw(882), ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+	require.True(sdk.RatEq(t, sdk.NewRat(20902, 1000), keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+}
+
+func TestDistributeFromFeePool(t *testing.T) {
+	ctx, ck, _, keeper := createTestInput(t)
+
+	// without bonded validators the whole fees fund the community pool
+	collectFees(t, ctx, keeper, 1000)
+	keeper.AllocateTokens(ctx, sdk.OneRat(), nil)
+	pool := keeper.GetFeePool(ctx).CommunityPool
+	require.True(t, pool.AmountOf("steak").GT(sdk.NewRat(999)))
+
+	// spends exceeding the pool are refused
+	balance := ck.GetCoins(ctx, addrs[2])
+	err := keeper.DistributeFromFeePool(ctx, sdk.Coins{sdk.NewCoin("foocoin", 1), sdk.NewCoin("steak", 300)}, addrs[2])
+	require.NotNil(t, err)
+	require.Equal(t, pool, keeper.GetFeePool(ctx).CommunityPool)
+
+	err = keeper.DistributeFromFeePool(ctx, sdk.Coins{sdk.NewCoin("steak", 300)}, addrs[2])
+	require.Nil(t, err)
+	require.True(sdk.RatEq(t, pool.AmountOf("steak").Sub(sdk.NewRat(300)), keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+	require.True(t, ck.GetCoins(ctx, addrs[2]).IsEqual(balance.Plus(sdk.Coins{sdk.NewCoin("steak", 300)})))
+}
+
+func TestParamChange(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	require.Nil(t, keeper.CheckParamChange(string(KeyCommunityTax), `"1/10"`))
+	require.NotNil(t, keeper.CheckParamChange(string(KeyCommunityTax), `"3/2"`))
+	require.NotNil(t, keeper.CheckParamChange("Unknown", `"1/10"`))
+
+	err := keeper.ApplyParamChange(ctx, string(KeyCommunityTax), `"1/10"`)
+	require.Nil(t, err)
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 10), keeper.GetParams(ctx).CommunityTax))
+
+	// the fractions can't add up to more than the rewards
+	err = keeper.ApplyParamChange(ctx, string(KeyBonusProposerReward), `"95/100"`)
+	require.NotNil(t, err)
+	require.True(sdk.RatEq(t, sdk.NewRat(4, 100), keeper.GetParams(ctx).BonusProposerReward))
+}
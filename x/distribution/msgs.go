@@ -0,0 +1,95 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var cdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "distribution"
+
+// verify interface at compile time
+var _, _ sdk.Msg = &MsgWithdrawDelegatorReward{}, &MsgWithdrawValidatorCommission{}
+
+// MsgWithdrawDelegatorReward - struct for withdrawing the rewards of a delegation
+type MsgWithdrawDelegatorReward struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+}
+
+func NewMsgWithdrawDelegatorReward(delegatorAddr, validatorAddr sdk.Address) MsgWithdrawDelegatorReward {
+	return MsgWithdrawDelegatorReward{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawDelegatorReward) Type() string              { return MsgType }
+func (msg MsgWithdrawDelegatorReward) GetSigners() []sdk.Address { return []sdk.Address{msg.DelegatorAddr} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawDelegatorReward) GetSignBytes() []byte {
+	b, err := cdc.MarshalJSON(struct {
+		DelegatorAddr string `json:"delegator_addr"`
+		ValidatorAddr string `json:"validator_addr"`
+	}{
+		DelegatorAddr: sdk.MustBech32ifyAcc(msg.DelegatorAddr),
+		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgWithdrawDelegatorReward) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrBadDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrBadValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgWithdrawValidatorCommission - struct for withdrawing the commission of a validator
+type MsgWithdrawValidatorCommission struct {
+	ValidatorAddr sdk.Address `json:"validator_addr"` // address of the validator owner
+}
+
+func NewMsgWithdrawValidatorCommission(validatorAddr sdk.Address) MsgWithdrawValidatorCommission {
+	return MsgWithdrawValidatorCommission{
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawValidatorCommission) Type() string              { return MsgType }
+func (msg MsgWithdrawValidatorCommission) GetSigners() []sdk.Address { return []sdk.Address{msg.ValidatorAddr} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawValidatorCommission) GetSignBytes() []byte {
+	b, err := cdc.MarshalJSON(struct {
+		ValidatorAddr string `json:"validator_addr"`
+	}{
+		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgWithdrawValidatorCommission) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrBadValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
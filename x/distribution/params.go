@@ -0,0 +1,109 @@
+package distribution
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// default paramspace for the distribution params
+const DefaultParamspace = "distribution"
+
+// keys of the params in the paramspace
+var (
+	KeyCommunityTax        = []byte("CommunityTax")
+	KeyBaseProposerReward  = []byte("BaseProposerReward")
+	KeyBonusProposerReward = []byte("BonusProposerReward")
+)
+
+// Params - parameters of the reward distribution
+type Params struct {
+	CommunityTax        sdk.Rat `json:"community_tax"`         // fraction of the rewards funding the community pool
+	BaseProposerReward  sdk.Rat `json:"base_proposer_reward"`  // fraction of the rewards always paid to the block proposer
+	BonusProposerReward sdk.Rat `json:"bonus_proposer_reward"` // additional fraction paid to the proposer for the precommits it included
+}
+
+// DefaultParams returns the default distribution parameters
+func DefaultParams() Params {
+	return Params{
+		CommunityTax:        sdk.NewRat(2, 100),
+		BaseProposerReward:  sdk.NewRat(1, 100),
+		BonusProposerReward: sdk.NewRat(4, 100),
+	}
+}
+
+// Validate checks that the fractions are valid and don't exceed the rewards
+func (p Params) Validate() sdk.Error {
+	fractions := []sdk.Rat{p.CommunityTax, p.BaseProposerReward, p.BonusProposerReward}
+	total := sdk.ZeroRat()
+	for _, fraction := range fractions {
+		if fraction.Rat == nil || fraction.LT(sdk.ZeroRat()) {
+			return ErrInvalidParams(DefaultCodespace, "fractions must be non-negative")
+		}
+		total = total.Add(fraction)
+	}
+	if total.GT(sdk.OneRat()) {
+		return ErrInvalidParams(DefaultCodespace, "fractions must not add up to more than one")
+	}
+	return nil
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyCommunityTax, &p.CommunityTax, validateFraction},
+		{KeyBaseProposerReward, &p.BaseProposerReward, validateFraction},
+		{KeyBonusProposerReward, &p.BonusProposerReward, validateFraction},
+	}
+}
+
+// ParamKeyTable declares the distribution params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func validateFraction(value interface{}) error {
+	rat := value.(sdk.Rat)
+	if rat.Rat == nil || rat.LT(sdk.ZeroRat()) || rat.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// GetParams returns the distribution params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
+// SetParams sets the distribution params
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// CheckParamChange checks a change of a distribution param proposed to governance
+func (k Keeper) CheckParamChange(key string, value string) sdk.Error {
+	return k.paramspace.CheckParamChange(key, value)
+}
+
+// ApplyParamChange applies a change of a distribution param, refusing the
+// changes which would make the fractions add up to more than the rewards
+func (k Keeper) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	// decode the change into the current params, without writing it
+	cacheCtx, _ := ctx.CacheContext()
+	err := k.paramspace.ApplyParamChange(cacheCtx, key, value)
+	if err != nil {
+		return err
+	}
+	newParams := k.GetParams(cacheCtx)
+	err = newParams.Validate()
+	if err != nil {
+		return err
+	}
+
+	k.SetParams(ctx, newParams)
+	return nil
+}
@@ -0,0 +1,127 @@
+package distribution
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	addrs = []sdk.Address{
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6160"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6161"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6162"),
+	}
+	pks = []crypto.PubKey{
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB50"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB51"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB52"),
+	}
+	initCoins sdk.Int = sdk.NewInt(200)
+)
+
+func createTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyFee := sdk.NewKVStoreKey("fee")
+	keyDistr := sdk.NewKVStoreKey("distr")
+	keyParams := sdk.NewKVStoreKey("params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFee, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewTMLogger(os.Stdout))
+	cdc := createTestCodec()
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
+	ck := bank.NewKeeper(cdc, keyBank, accountMapper).WithModuleAccounts(map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Minter, auth.Burner, auth.Staking},
+		ModuleName:            nil,
+	})
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFee)
+	paramsKeeper := params.NewKeeper(cdc, keyParams)
+	sk := stake.NewKeeper(cdc, keyStake, ck, paramsKeeper.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
+	keeper := NewKeeper(cdc, keyDistr, ck, sk, fck, paramsKeeper.Subspace(DefaultParamspace), DefaultCodespace)
+	sk = sk.WithHooks(keeper.Hooks())
+
+	genesis := stake.DefaultGenesisState()
+	genesis.Pool.LooseTokens = initCoins.MulRaw(int64(len(addrs))).Int64()
+	stake.InitGenesis(ctx, sk, genesis)
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	for _, addr := range addrs {
+		_, _, err = ck.MintCoins(ctx, addr, sdk.Coins{
+			{sk.GetParams(ctx).BondDenom, initCoins},
+		})
+	}
+	require.Nil(t, err)
+	return ctx, ck, sk, keeper
+}
+
+func newPubKey(pk string) (res crypto.PubKey) {
+	pkBytes, err := hex.DecodeString(pk)
+	if err != nil {
+		panic(err)
+	}
+	var pkEd crypto.PubKeyEd25519
+	copy(pkEd[:], pkBytes[:])
+	return pkEd
+}
+
+func testAddr(addr string) sdk.Address {
+	res := []byte(addr)
+	return res
+}
+
+func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt sdk.Int, commission sdk.Rat) stake.MsgCreateValidator {
+	return stake.MsgCreateValidator{
+		Description:          stake.Description{},
+		ValidatorAddr:        address,
+		PubKey:               pubKey,
+		SelfDelegation:       sdk.Coin{"steak", amt},
+		Commission:           commission,
+		CommissionMax:        sdk.OneRat(),
+		CommissionChangeRate: sdk.OneRat(),
+		MinSelfDelegation:    sdk.OneInt(),
+	}
+}
+
+func newTestMsgDelegate(delegatorAddr, validatorAddr sdk.Address, amt sdk.Int) stake.MsgDelegate {
+	return stake.MsgDelegate{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+		Bond:          sdk.Coin{"steak", amt},
+	}
+}
@@ -0,0 +1,38 @@
+package distribution
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// distribution begin block functionality
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) {
+
+	// fraction of the voting power which precommitted the previous block
+	var signedPower, totalPower int64
+	for _, signingValidator := range req.Validators {
+		totalPower += signingValidator.Validator.Power
+		if signingValidator.SignedLastBlock {
+			signedPower += signingValidator.Validator.Power
+		}
+	}
+	precommitFraction := sdk.ZeroRat()
+	if totalPower > 0 {
+		precommitFraction = sdk.NewRat(signedPower, totalPower)
+	}
+
+	// the rewards of the previous block include the bonus of its proposer
+	k.AllocateTokens(ctx, precommitFraction, k.GetPreviousProposer(ctx))
+
+	// record the proposer of this block, to be rewarded in the next one
+	var proposer sdk.Address
+	pubkey, err := tmtypes.PB2TM.PubKey(req.Header.Proposer.PubKey)
+	if err == nil {
+		if validator, found := k.sk.GetValidatorByPubKey(ctx, pubkey); found {
+			proposer = validator.Owner
+		}
+	}
+	k.SetPreviousProposer(ctx, proposer)
+}
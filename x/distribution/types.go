@@ -0,0 +1,34 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ModuleName is the name of the module account holding the rewards not yet withdrawn
+const ModuleName = "distribution"
+
+// FeePool - rewards kept by the module rather than by a validator
+type FeePool struct {
+	CommunityPool DecCoins `json:"community_pool"` // rewards funding the community
+}
+
+// InitialFeePool returns an empty fee pool
+func InitialFeePool() FeePool {
+	return FeePool{
+		CommunityPool: DecCoins{},
+	}
+}
+
+// ValidatorDistInfo - rewards accounting of a validator
+type ValidatorDistInfo struct {
+	ValidatorAddr   sdk.Address `json:"validator_addr"`
+	Commission      DecCoins    `json:"commission"`        // commission earned and not yet withdrawn
+	RewardsPerShare DecCoins    `json:"rewards_per_share"` // accumulated delegator rewards per delegator share
+}
+
+// DelegationDistInfo - rewards accounting of a delegation
+type DelegationDistInfo struct {
+	DelegatorAddr   sdk.Address `json:"delegator_addr"`
+	ValidatorAddr   sdk.Address `json:"validator_addr"`
+	RewardsPerShare DecCoins    `json:"rewards_per_share"` // rewards per share of the validator when last withdrawn
+}
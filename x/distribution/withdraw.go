@@ -0,0 +1,57 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithdrawDelegatorReward pays a delegator the rewards accrued to its shares
+// of a validator since its last withdrawal. The fractional change funds the
+// community pool.
+func (k Keeper) WithdrawDelegatorReward(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	delegation, found := k.sk.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	if !found {
+		return nil, ErrNoDelegationForAddress(k.codespace)
+	}
+
+	valInfo := k.GetValidatorDistInfo(ctx, validatorAddr)
+	delInfo := k.GetDelegationDistInfo(ctx, delegatorAddr, validatorAddr)
+	owed := valInfo.RewardsPerShare.Minus(delInfo.RewardsPerShare).MulRat(delegation.Shares)
+	reward, change := owed.TruncateDecimal()
+
+	delInfo.RewardsPerShare = valInfo.RewardsPerShare
+	k.SetDelegationDistInfo(ctx, delInfo)
+	if !change.IsZero() {
+		feePool := k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Plus(change)
+		k.SetFeePool(ctx, feePool)
+	}
+
+	if !reward.IsZero() {
+		_, err := k.ck.SendCoinsFromModuleToAccount(ctx, ModuleName, delegatorAddr, reward)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reward, nil
+}
+
+// WithdrawValidatorCommission pays a validator owner the whole coins of the
+// commission it earned, the fractional change stays accounted to it
+func (k Keeper) WithdrawValidatorCommission(ctx sdk.Context, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	if _, found := k.sk.GetValidator(ctx, validatorAddr); !found {
+		return nil, ErrNoValidatorForAddress(k.codespace)
+	}
+
+	info := k.GetValidatorDistInfo(ctx, validatorAddr)
+	commission, change := info.Commission.TruncateDecimal()
+	info.Commission = change
+	k.SetValidatorDistInfo(ctx, info)
+
+	if !commission.IsZero() {
+		_, err := k.ck.SendCoinsFromModuleToAccount(ctx, ModuleName, validatorAddr, commission)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commission, nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -11,19 +12,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	"github.com/pkg/errors"
 )
 
 const (
-	flagProposalID   = "proposalID"
-	flagTitle        = "title"
-	flagDescription  = "description"
-	flagProposalType = "type"
-	flagDeposit      = "deposit"
-	flagProposer     = "proposer"
-	flagDepositer    = "depositer"
-	flagVoter        = "voter"
-	flagOption       = "option"
+	flagProposalID     = "proposalID"
+	flagTitle          = "title"
+	flagDescription    = "description"
+	flagProposalType   = "type"
+	flagDeposit        = "deposit"
+	flagProposer       = "proposer"
+	flagDepositer      = "depositer"
+	flagVoter          = "voter"
+	flagOption         = "option"
+	flagParamChange    = "param-change"
+	flagUpgradeName    = "upgrade-name"
+	flagUpgradeHeight  = "upgrade-height"
+	flagUpgradeInfo    = "upgrade-info"
+	flagSpendRecipient = "spend-recipient"
+	flagSpendAmount    = "spend-amount"
 )
 
 // submit a proposal tx
@@ -53,8 +62,38 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 				return err
 			}
 
+			paramChanges, err := cmd.Flags().GetStringArray(flagParamChange)
+			if err != nil {
+				return err
+			}
+
 			// create the message
 			msg := gov.NewMsgSubmitProposal(title, description, proposalType, from, amount)
+			switch proposalType {
+			case gov.ProposalTypeParameterChange:
+				changes, err := parseParamChanges(paramChanges)
+				if err != nil {
+					return err
+				}
+				msg.Content = gov.ParamChangeContent{Changes: changes}
+			case gov.ProposalTypeSoftwareUpgrade:
+				msg.Content = gov.SoftwareUpgradeContent{Plan: upgrade.Plan{
+					Name:   viper.GetString(flagUpgradeName),
+					Height: viper.GetInt64(flagUpgradeHeight),
+					Info:   viper.GetString(flagUpgradeInfo),
+				}}
+			case gov.ProposalTypeCommunityPoolSpend:
+				var spend gov.CommunityPoolSpend
+				spend.Recipient, err = sdk.GetAccAddressBech32(viper.GetString(flagSpendRecipient))
+				if err != nil {
+					return err
+				}
+				spend.Amount, err = sdk.ParseCoins(viper.GetString(flagSpendAmount))
+				if err != nil {
+					return err
+				}
+				msg.Content = spend
+			}
 
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -79,10 +118,34 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposer, "", "proposer of proposal")
+	cmd.Flags().StringArray(flagParamChange, nil, "parameter change of a ParameterChange proposal as subspace/key=value, the value being JSON (may provide multiple)")
+	cmd.Flags().String(flagUpgradeName, "", "name of the upgrade handler of a SoftwareUpgrade proposal")
+	cmd.Flags().Int64(flagUpgradeHeight, 0, "height of the upgrade of a SoftwareUpgrade proposal")
+	cmd.Flags().String(flagUpgradeInfo, "", "how to get the binary of a SoftwareUpgrade proposal, eg. a release URL")
+	cmd.Flags().String(flagSpendRecipient, "", "recipient of a CommunityPoolSpend proposal")
+	cmd.Flags().String(flagSpendAmount, "", "coins paid by a CommunityPoolSpend proposal")
 
 	return cmd
 }
 
+// parse parameter changes formatted as subspace/key=value
+func parseParamChanges(strChanges []string) ([]gov.ParamChange, error) {
+	changes := make([]gov.ParamChange, 0, len(strChanges))
+	for _, strChange := range strChanges {
+		eq := strings.Index(strChange, "=")
+		slash := strings.Index(strChange, "/")
+		if eq < 0 || slash < 0 || slash > eq {
+			return nil, errors.Errorf("invalid parameter change %q, expected subspace/key=value", strChange)
+		}
+		changes = append(changes, gov.ParamChange{
+			Subspace: strChange[:slash],
+			Key:      strChange[slash+1 : eq],
+			Value:    strChange[eq+1:],
+		})
+	}
+	return changes, nil
+}
+
 // set a new Deposit transaction
 func GetCmdDeposit(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -129,11 +192,50 @@ func GetCmdDeposit(cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
+// cancel a proposal during its deposit period
+func GetCmdCancelProposal(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-proposal",
+		Short: "cancel your own proposal during its deposit period, part of the deposits are burned",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proposer, err := sdk.GetAccAddressBech32(viper.GetString(flagProposer))
+			if err != nil {
+				return err
+			}
+
+			proposalID := viper.GetInt64(flagProposalID)
+
+			// create the message
+			msg := gov.NewMsgCancelProposal(proposer, proposalID)
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal to cancel")
+	cmd.Flags().String(flagProposer, "", "proposer of the proposal")
+
+	return cmd
+}
+
 // set a new Vote transaction
 func GetCmdVote(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "vote",
-		Short: "vote for an active proposal, options: Yes/No/NoWithVeto/Abstain",
+		Short: "vote for an active proposal, options: Yes/No/NoWithVeto/Abstain, or weighted options such as Yes=0.6,No=0.4",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			bechVoter := viper.GetString(flagVoter)
@@ -146,20 +248,23 @@ func GetCmdVote(cdc *wire.Codec) *cobra.Command {
 
 			option := viper.GetString(flagOption)
 
-			byteVoteOption, err := gov.StringToVoteOption(option)
+			voteOptions, err := gov.StringToWeightedVoteOptions(option)
 			if err != nil {
 				return err
 			}
 
-			// create the message
-			msg := gov.NewMsgVote(voter, proposalID, byteVoteOption)
+			// create the message, splitting the vote only across several options
+			var msg sdk.Msg = gov.NewMsgVoteWeighted(voter, proposalID, voteOptions)
+			if len(voteOptions) == 1 && voteOptions[0].Weight.Equal(sdk.OneRat()) {
+				msg = gov.NewMsgVote(voter, proposalID, voteOptions[0].Option)
+			}
 
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Vote[Voter:%s,ProposalID:%d,Option:%s]", bechVoter, msg.ProposalID, gov.VoteOptionToString(msg.Option))
+			fmt.Printf("Vote[Voter:%s,ProposalID:%d,Option:%s]", bechVoter, proposalID, gov.WeightedVoteOptionsToString(voteOptions))
 
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
@@ -175,7 +280,7 @@ func GetCmdVote(cdc *wire.Codec) *cobra.Command {
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
 	cmd.Flags().String(flagVoter, "", "bech32 voter address")
-	cmd.Flags().String(flagOption, "", "vote option {Yes, No, NoWithVeto, Abstain}")
+	cmd.Flags().String(flagOption, "", "vote option {Yes, No, NoWithVeto, Abstain}, or comma separated options weighted to sum to one")
 
 	return cmd
 }
@@ -249,3 +354,32 @@ func GetCmdQueryVote(storeName string, cdc *wire.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// Command to Get the current Tally of a Proposal
+func GetCmdQueryTally(storeName string, stakeStoreName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-tally",
+		Short: "query the current tally of a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proposalID := viper.GetInt64(flagProposalID)
+
+			ctx := context.NewCoreContextFromViper()
+
+			tallyResults, err := utils.QueryTally(ctx, cdc, storeName, stakeStoreName, proposalID)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, tallyResults)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal being tallied")
+
+	return cmd
+}
@@ -9,6 +9,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
@@ -20,6 +22,7 @@ const (
 	RestDepositer  = "depositer"
 	RestVoter      = "voter"
 	storeName      = "gov"
+	stakeStoreName = "stake"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
@@ -27,21 +30,27 @@ func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 	r.HandleFunc("/gov/proposals", postProposalHandlerFn(cdc, ctx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), depositHandlerFn(cdc, ctx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), voteHandlerFn(cdc, ctx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/cancel", RestProposalID), cancelProposalHandlerFn(cdc, ctx)).Methods("POST")
 
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}", RestProposalID), queryProposalHandlerFn(cdc)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer), queryDepositHandlerFn(cdc)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter), queryVoteHandlerFn(cdc)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/tally", RestProposalID), queryTallyHandlerFn(cdc)).Methods("GET")
 
 	r.HandleFunc("/gov/proposals", queryProposalsWithParameterFn(cdc)).Methods("GET")
 }
 
 type postProposalReq struct {
-	BaseReq        baseReq   `json:"base_req"`
-	Title          string    `json:"title"`           //  Title of the proposal
-	Description    string    `json:"description"`     //  Description of the proposal
-	ProposalType   string    `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       string    `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        baseReq           `json:"base_req"`
+	Title          string            `json:"title"`           //  Title of the proposal
+	Description    string            `json:"description"`     //  Description of the proposal
+	ProposalType   string            `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       string            `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	ParamChanges   []gov.ParamChange `json:"param_changes"`   // Changes of a ParameterChange proposal
+	UpgradePlan    upgrade.Plan      `json:"upgrade_plan"`    // Plan of a SoftwareUpgrade proposal
+	SpendRecipient string            `json:"spend_recipient"` // Recipient of a CommunityPoolSpend proposal
+	SpendAmount    sdk.Coins         `json:"spend_amount"`    // Coins paid by a CommunityPoolSpend proposal
 }
 
 type depositReq struct {
@@ -50,10 +59,15 @@ type depositReq struct {
 	Amount    sdk.Coins `json:"amount"`    // Coins to add to the proposal's deposit
 }
 
+type cancelProposalReq struct {
+	BaseReq  baseReq `json:"base_req"`
+	Proposer string  `json:"proposer"` // Address of the proposer
+}
+
 type voteReq struct {
 	BaseReq baseReq `json:"base_req"`
 	Voter   string  `json:"voter"`  //  address of the voter
-	Option  string  `json:"option"` //  option from OptionSet chosen by the voter
+	Option  string  `json:"option"` //  option from OptionSet chosen by the voter, or weighted options such as Yes=0.6,No=0.4
 }
 
 func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
@@ -82,6 +96,19 @@ func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.Handle
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalTypeByte, proposer, req.InitialDeposit)
+		switch proposalTypeByte {
+		case gov.ProposalTypeParameterChange:
+			msg.Content = gov.ParamChangeContent{Changes: req.ParamChanges}
+		case gov.ProposalTypeSoftwareUpgrade:
+			msg.Content = gov.SoftwareUpgradeContent{Plan: req.UpgradePlan}
+		case gov.ProposalTypeCommunityPoolSpend:
+			recipient, err := sdk.GetAccAddressBech32(req.SpendRecipient)
+			if err != nil {
+				writeErr(&w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg.Content = gov.CommunityPoolSpend{Recipient: recipient, Amount: req.SpendAmount}
+		}
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
@@ -141,6 +168,54 @@ func depositHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc
 	}
 }
 
+func cancelProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			err := errors.New("proposalId required but not specified")
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		proposalID, err := strconv.ParseInt(strProposalID, 10, 64)
+		if err != nil {
+			err := errors.Errorf("proposalID [%d] is not positive", proposalID)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var req cancelProposalReq
+		err = buildReq(w, r, cdc, &req)
+		if err != nil {
+			return
+		}
+
+		if !req.BaseReq.baseReqValidate(w) {
+			return
+		}
+
+		proposer, err := sdk.GetAccAddressBech32(req.Proposer)
+		if err != nil {
+			writeErr(&w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgCancelProposal(proposer, proposalID)
+		err = msg.ValidateBasic()
+		if err != nil {
+			writeErr(&w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// sign
+		signAndBuild(w, ctx, req.BaseReq, msg, cdc)
+	}
+}
+
 func voteHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -176,14 +251,17 @@ func voteHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
 			return
 		}
 
-		voteOptionByte, err := gov.StringToVoteOption(req.Option)
+		voteOptions, err := gov.StringToWeightedVoteOptions(req.Option)
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		// create the message
-		msg := gov.NewMsgVote(voter, proposalID, voteOptionByte)
+		// create the message, splitting the vote only across several options
+		var msg sdk.Msg = gov.NewMsgVoteWeighted(voter, proposalID, voteOptions)
+		if len(voteOptions) == 1 && voteOptions[0].Weight.Equal(sdk.OneRat()) {
+			msg = gov.NewMsgVote(voter, proposalID, voteOptions[0].Option)
+		}
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
@@ -236,6 +314,45 @@ func queryProposalHandlerFn(cdc *wire.Codec) http.HandlerFunc {
 	}
 }
 
+func queryTallyHandlerFn(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			err := errors.New("proposalId required but not specified")
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		proposalID, err := strconv.ParseInt(strProposalID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			err := errors.Errorf("proposalID [%s] is not a number", strProposalID)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		ctx := context.NewCoreContextFromViper()
+
+		tallyResults, err := utils.QueryTally(ctx, cdc, storeName, stakeStoreName, proposalID)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		output, err := wire.MarshalJSONIndent(cdc, tallyResults)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Write(output)
+	}
+}
+
 func queryDepositHandlerFn(cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
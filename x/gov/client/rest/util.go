@@ -16,6 +16,7 @@ type baseReq struct {
 	ChainID       string `json:"chain_id"`
 	AccountNumber int64  `json:"account_number"`
 	Sequence      int64  `json:"sequence"`
+	TimeoutHeight int64  `json:"timeout_height"`
 	Gas           int64  `json:"gas"`
 }
 
@@ -71,6 +72,7 @@ func writeErr(w *http.ResponseWriter, status int, msg string) {
 func signAndBuild(w http.ResponseWriter, ctx context.CoreContext, baseReq baseReq, msg sdk.Msg, cdc *wire.Codec) {
 	ctx = ctx.WithAccountNumber(baseReq.AccountNumber)
 	ctx = ctx.WithSequence(baseReq.Sequence)
+	ctx = ctx.WithTimeoutHeight(baseReq.TimeoutHeight)
 	ctx = ctx.WithChainID(baseReq.ChainID)
 
 	// add gas to context
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/pkg/errors"
+)
+
+// QueryTally tallies the votes cast so far on a proposal in its voting
+// period, from the votes and the bonded validators and delegations in the
+// stores. It returns the stored result of proposals no longer being voted on.
+func QueryTally(ctx context.CoreContext, cdc *wire.Codec, storeName string, stakeStoreName string, proposalID int64) (gov.TallyResult, error) {
+	res, err := ctx.QueryStore(gov.KeyProposal(proposalID), storeName)
+	if len(res) == 0 || err != nil {
+		return gov.TallyResult{}, errors.Errorf("proposalID [%d] does not exist", proposalID)
+	}
+	var proposal gov.Proposal
+	cdc.MustUnmarshalBinary(res, &proposal)
+	if proposal.GetStatus() != gov.StatusVotingPeriod {
+		return proposal.GetTallyResult(), nil
+	}
+
+	resKVs, err := ctx.QuerySubspace(cdc, gov.KeyVotesSubspace(proposalID), storeName)
+	if err != nil {
+		return gov.TallyResult{}, err
+	}
+	var votes []gov.Vote
+	for _, KV := range resKVs {
+		var vote gov.Vote
+		cdc.MustUnmarshalBinary(KV.Value, &vote)
+		votes = append(votes, vote)
+	}
+
+	resKVs, err = ctx.QuerySubspace(cdc, stake.ValidatorsKey, stakeStoreName)
+	if err != nil {
+		return gov.TallyResult{}, err
+	}
+	var validators []sdk.Validator
+	for _, KV := range resKVs {
+		var validator stake.Validator
+		cdc.MustUnmarshalBinary(KV.Value, &validator)
+		if validator.GetStatus() == sdk.Bonded {
+			validators = append(validators, validator)
+		}
+	}
+
+	delegations := make(map[string][]sdk.Delegation)
+	for _, vote := range votes {
+		resKVs, err = ctx.QuerySubspace(cdc, stake.GetDelegationsKey(vote.Voter, cdc), stakeStoreName)
+		if err != nil {
+			return gov.TallyResult{}, err
+		}
+		for _, KV := range resKVs {
+			var delegation stake.Delegation
+			cdc.MustUnmarshalBinary(KV.Value, &delegation)
+			delegations[vote.Voter.String()] = append(delegations[vote.Voter.String()], delegation)
+		}
+	}
+
+	tallyResults, _ := gov.TallyVotes(validators, votes, delegations)
+	return tallyResults, nil
+}
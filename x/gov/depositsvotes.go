@@ -1,6 +1,9 @@
 package gov
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -18,9 +21,24 @@ const (
 
 // Vote
 type Vote struct {
-	Voter      sdk.Address `json:"voter"`       //  address of the voter
-	ProposalID int64       `json:"proposal_id"` //  proposalID of the proposal
-	Option     VoteOption  `json:"option"`      //  option from OptionSet chosen by the voter
+	Voter      sdk.Address          `json:"voter"`       //  address of the voter
+	ProposalID int64                `json:"proposal_id"` //  proposalID of the proposal
+	Options    []WeightedVoteOption `json:"options"`     //  options from OptionSet chosen by the voter, with the part of the voting power cast on each
+}
+
+// WeightedVoteOption casts a part of the voting power of a voter on an option
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"` //  option from OptionSet
+	Weight sdk.Rat    `json:"weight"` //  part of the voting power cast on the option
+}
+
+func (o WeightedVoteOption) String() string {
+	return fmt.Sprintf("%s=%s", VoteOptionToString(o.Option), o.Weight.FloatString())
+}
+
+// options of a vote casting all the voting power on a single option
+func NewNonSplitVoteOption(option VoteOption) []WeightedVoteOption {
+	return []WeightedVoteOption{{option, sdk.OneRat()}}
 }
 
 // Deposit
@@ -56,6 +74,64 @@ func validVoteOption(option VoteOption) bool {
 	return false
 }
 
+// valid options are distinct, with positive weights summing to one
+func validWeightedVoteOptions(options []WeightedVoteOption) bool {
+	if len(options) == 0 {
+		return false
+	}
+	seen := make(map[VoteOption]bool)
+	totalWeight := sdk.ZeroRat()
+	for _, option := range options {
+		if !validVoteOption(option.Option) || seen[option.Option] {
+			return false
+		}
+		if option.Weight.Rat == nil || !option.Weight.GT(sdk.ZeroRat()) {
+			return false
+		}
+		seen[option.Option] = true
+		totalWeight = totalWeight.Add(option.Weight)
+	}
+	return totalWeight.Equal(sdk.OneRat())
+}
+
+// WeightedVoteOptionsToString for pretty prints of split votes
+func WeightedVoteOptionsToString(options []WeightedVoteOption) string {
+	strs := make([]string, len(options))
+	for i, option := range options {
+		strs[i] = option.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// String to weighted vote options, eg. "Yes=0.6,No=0.4". An option without a
+// weight is given all of it.
+func StringToWeightedVoteOptions(str string) ([]WeightedVoteOption, sdk.Error) {
+	if !strings.Contains(str, "=") {
+		option, err := StringToVoteOption(str)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonSplitVoteOption(option), nil
+	}
+	var options []WeightedVoteOption
+	for _, strOption := range strings.Split(str, ",") {
+		fields := strings.SplitN(strOption, "=", 2)
+		if len(fields) != 2 {
+			return nil, ErrInvalidVote(DefaultCodespace, str)
+		}
+		option, err := StringToVoteOption(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, err
+		}
+		weight, err := sdk.NewRatFromDecimal(strings.TrimSpace(fields[1]), 10)
+		if err != nil {
+			return nil, ErrInvalidVote(DefaultCodespace, str)
+		}
+		options = append(options, WeightedVoteOption{option, weight})
+	}
+	return options, nil
+}
+
 // String to proposalType byte.  Returns ff if invalid.
 func StringToVoteOption(str string) (VoteOption, sdk.Error) {
 	switch str {
@@ -96,15 +172,19 @@ func DepositToRest(deposit Deposit) DepositRest {
 type VoteRest struct {
 	Voter      string `json:"voter"`       //  address of the voter
 	ProposalID int64  `json:"proposal_id"` //  proposalID of the proposal
-	Option     string `json:"option"`
+	Option     string `json:"option"`      //  option of a vote on a single option, or the weighted options of a split vote
 }
 
 // Turn any Vote to a VoteRest
 func VoteToRest(vote Vote) VoteRest {
 	bechAddr, _ := sdk.Bech32ifyAcc(vote.Voter)
+	option := WeightedVoteOptionsToString(vote.Options)
+	if len(vote.Options) == 1 {
+		option = VoteOptionToString(vote.Options[0].Option)
+	}
 	return VoteRest{
 		Voter:      bechAddr,
 		ProposalID: vote.ProposalID,
-		Option:     VoteOptionToString(vote.Option),
+		Option:     option,
 	}
 }
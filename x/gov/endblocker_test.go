@@ -6,7 +6,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -14,10 +18,12 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{})
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
+	depositPeriod := keeper.GetDepositProcedure(ctx).MaxDepositPeriod
 
 	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
+	addr0Initial := keeper.ck.GetCoins(ctx, addrs[0])
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
@@ -27,17 +33,43 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	EndBlocker(ctx, keeper)
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
-	ctx = ctx.WithBlockHeight(250)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: depositPeriod + 50})
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
 	EndBlocker(ctx, keeper)
 	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+
+	// the deposit of a proposal which never reached MinDeposit is burned
+	require.Equal(t, addr0Initial.Minus(sdk.Coins{sdk.NewCoin("steak", 5)}), keeper.ck.GetCoins(ctx, addrs[0]))
+	require.True(t, keeper.ck.GetModuleCoins(ctx, ModuleName).IsZero())
+}
+
+func TestTickExpiredDepositPeriodRefund(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+
+	depositProcedure := keeper.GetDepositProcedure(ctx)
+	depositProcedure.BurnUnmetDeposit = false
+	keeper.setDepositProcedure(ctx, depositProcedure)
+
+	addr0Initial := keeper.ck.GetCoins(ctx, addrs[0])
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)}))
+	require.True(t, res.IsOK())
+	require.Equal(t, addr0Initial.Minus(sdk.Coins{sdk.NewCoin("steak", 5)}), keeper.ck.GetCoins(ctx, addrs[0]))
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: depositProcedure.MaxDepositPeriod})
+	EndBlocker(ctx, keeper)
+	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
+	require.Equal(t, addr0Initial, keeper.ck.GetCoins(ctx, addrs[0]))
+	require.True(t, keeper.ck.GetModuleCoins(ctx, ModuleName).IsZero())
 }
 
 func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
@@ -45,6 +77,7 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{})
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
+	depositPeriod := keeper.GetDepositProcedure(ctx).MaxDepositPeriod
 
 	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
@@ -58,7 +91,7 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	EndBlocker(ctx, keeper)
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
@@ -67,14 +100,14 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	res = govHandler(ctx, newProposalMsg2)
 	require.True(t, res.IsOK())
 
-	ctx = ctx.WithBlockHeight(205)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: depositPeriod + 5})
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
 	EndBlocker(ctx, keeper)
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
-	ctx = ctx.WithBlockHeight(215)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: depositPeriod + 15})
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
 	EndBlocker(ctx, keeper)
@@ -104,7 +137,7 @@ func TestTickPassedDepositPeriod(t *testing.T) {
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	EndBlocker(ctx, keeper)
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
@@ -131,12 +164,16 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{})
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
+	votingPeriod := keeper.GetVotingProcedure(ctx).VotingPeriod
 
 	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
 	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
 
+	addr0Initial := keeper.ck.GetCoins(ctx, addrs[0])
+	addr1Initial := keeper.ck.GetCoins(ctx, addrs[1])
+
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)})
 
 	res := govHandler(ctx, newProposalMsg)
@@ -144,14 +181,14 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	var proposalID int64
 	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
 
-	ctx = ctx.WithBlockHeight(10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10})
 	newDepositMsg := NewMsgDeposit(addrs[1], proposalID, sdk.Coins{sdk.NewCoin("steak", 5)})
 	res = govHandler(ctx, newDepositMsg)
 	require.True(t, res.IsOK())
 
 	EndBlocker(ctx, keeper)
 
-	ctx = ctx.WithBlockHeight(215)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: votingPeriod + 15})
 	require.True(t, shouldPopActiveProposalQueue(ctx, keeper))
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 	require.True(t, depositsIterator.Valid())
@@ -165,4 +202,367 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	require.False(t, depositsIterator.Valid())
 	depositsIterator.Close()
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.True(t, EmptyTallyResult().Equals(keeper.GetProposal(ctx, proposalID).GetTallyResult()))
+
+	// the deposits of a rejected proposal which wasn't vetoed are refunded
+	require.Equal(t, addr0Initial, keeper.ck.GetCoins(ctx, addrs[0]))
+	require.Equal(t, addr1Initial, keeper.ck.GetCoins(ctx, addrs[1]))
+}
+
+func TestTickVetoedProposalBurnsDeposits(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	for _, addr := range addrs[:3] {
+		valCreateMsg := stake.NewMsgCreateValidator(addr, crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 20), dummyDescription)
+		res := stakeHandler(ctx, valCreateMsg)
+		require.True(t, res.IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+
+	addr3Initial := keeper.ck.GetCoins(ctx, addrs[3])
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[3], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+	res = govHandler(ctx, NewMsgVote(addrs[1], proposalID, OptionNoWithVeto))
+	require.True(t, res.IsOK())
+	res = govHandler(ctx, NewMsgVote(addrs[2], proposalID, OptionNoWithVeto))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	EndBlocker(ctx, keeper)
+
+	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, addr3Initial.Minus(sdk.Coins{sdk.NewCoin("steak", 10)}), keeper.ck.GetCoins(ctx, addrs[3]))
+	require.True(t, keeper.GetDepositedCoins(ctx).IsZero())
+	require.True(t, keeper.ck.GetModuleCoins(ctx, ModuleName).IsZero())
+}
+
+func TestTickPassedParamChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+	valCreateMsg = stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 10), dummyDescription)
+	res = stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+	require.Equal(t, 2, len(sk.GetValidatorsBonded(ctx)))
+
+	// changes are checked at submission
+	deposit := sdk.Coins{sdk.NewCoin("steak", 10)}
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", ParamChangeContent{[]ParamChange{{"bank", "SendEnabled/steak", "false"}}}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", ParamChangeContent{[]ParamChange{{"stake", "MaxValidators", "0"}}}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", ParamChangeContent{[]ParamChange{{"stake", "Unknown", "0"}}}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", ParamChangeContent{[]ParamChange{{"stake", "BondDenom", `"atom"`}}}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+
+	changes := []ParamChange{
+		{"stake", "MaxValidators", "1"},
+		{"gov", "votingprocedure", `{"voting_period":"100"}`},
+	}
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", ParamChangeContent{changes}, addrs[0], deposit))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	proposal, ok := keeper.GetProposal(ctx, proposalID).(*ParameterChangeProposal)
+	require.True(t, ok)
+	require.Equal(t, changes, proposal.Changes)
+	require.Equal(t, StatusVotingPeriod, proposal.GetStatus())
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+	res = govHandler(ctx, NewMsgVote(addrs[1], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, uint16(1), sk.GetParams(ctx).MaxValidators)
+
+	// the bonded validators are recomputed with the new maximum
+	bonded := sk.GetValidatorsBonded(ctx)
+	require.Equal(t, 1, len(bonded))
+	require.Equal(t, addrs[1], bonded[0].Owner)
+	require.Equal(t, int64(100), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.Contains(t, tags, sdk.MakeTag("paramChange", []byte("stake/MaxValidators")))
+	require.Contains(t, tags, sdk.MakeTag("paramChange", []byte("gov/votingprocedure")))
+}
+
+func TestApplyParamChangesAtomic(t *testing.T) {
+	mapp, keeper, sk, _, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	maxValidators := sk.GetParams(ctx).MaxValidators
+
+	// the second change fails, the first one is not written
+	err := keeper.ApplyParamChanges(ctx, []ParamChange{
+		{"stake", "MaxValidators", "50"},
+		{"bank", "SendEnabled/steak", "false"},
+	})
+	require.NotNil(t, err)
+	require.Equal(t, maxValidators, sk.GetParams(ctx).MaxValidators)
+
+	err = keeper.ApplyParamChanges(ctx, []ParamChange{{"stake", "MaxValidators", "50"}})
+	require.Nil(t, err)
+	require.Equal(t, uint16(50), sk.GetParams(ctx).MaxValidators)
+}
+
+// records the scheduled upgrade plans
+type testUpgradeScheduler struct {
+	plans []upgrade.Plan
+}
+
+func (s *testUpgradeScheduler) ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) sdk.Error {
+	s.plans = append(s.plans, plan)
+	return nil
+}
+
+func TestTickPassedSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	// upgrades are rejected without an upgrade scheduler
+	deposit := sdk.Coins{sdk.NewCoin("steak", 10)}
+	plan := upgrade.Plan{Name: "v2", Height: 1000, Info: "release v2"}
+	res = NewHandler(keeper)(ctx, NewMsgSubmitProposalWithContent("Test", "test", SoftwareUpgradeContent{plan}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+
+	scheduler := &testUpgradeScheduler{}
+	keeper = keeper.WithUpgradeScheduler(scheduler)
+	govHandler := NewHandler(keeper)
+
+	ctx = ctx.WithBlockHeight(1000)
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", SoftwareUpgradeContent{plan}, addrs[0], deposit))
+	require.False(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeight(10)
+	res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", SoftwareUpgradeContent{plan}, addrs[0], deposit))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	proposal, ok := keeper.GetProposal(ctx, proposalID).(*SoftwareUpgradeProposal)
+	require.True(t, ok)
+	require.Equal(t, plan, proposal.Plan)
+
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeight(210).WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, []upgrade.Plan{plan}, scheduler.plans)
+	require.Contains(t, tags, sdk.MakeTag("upgradeScheduled", []byte("v2")))
+}
+
+// pays the spends from a fixed pool
+type testPoolSpender struct {
+	pool sdk.Coins
+	paid map[string]sdk.Coins
+}
+
+func (s *testPoolSpender) DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, recipient sdk.Address) sdk.Error {
+	if !s.pool.IsGTE(amount) {
+		return sdk.ErrInsufficientCoins("insufficient community pool")
+	}
+	s.pool = s.pool.Minus(amount)
+	s.paid[recipient.String()] = amount
+	return nil
+}
+
+func TestTickPassedPoolSpendProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	// spends are rejected without a community pool spender
+	deposit := sdk.Coins{sdk.NewCoin("steak", 10)}
+	spend := CommunityPoolSpend{addrs[1], sdk.Coins{sdk.NewCoin("steak", 60)}}
+	res = NewHandler(keeper)(ctx, NewMsgSubmitProposalWithContent("Test", "test", spend, addrs[0], deposit))
+	require.False(t, res.IsOK())
+
+	spender := &testPoolSpender{sdk.Coins{sdk.NewCoin("steak", 100)}, make(map[string]sdk.Coins)}
+	keeper = keeper.WithCommunityPoolSpender(spender)
+	govHandler := NewHandler(keeper)
+
+	// the pool pays the first spend, the second one exceeds what remains
+	var proposalIDs []int64
+	for i := 0; i < 2; i++ {
+		res = govHandler(ctx, NewMsgSubmitProposalWithContent("Test", "test", spend, addrs[0], deposit))
+		require.True(t, res.IsOK())
+		var proposalID int64
+		keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+		res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+		require.True(t, res.IsOK())
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+	proposal, ok := keeper.GetProposal(ctx, proposalIDs[0]).(*CommunityPoolSpendProposal)
+	require.True(t, ok)
+	require.Equal(t, spend, proposal.Spend)
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+	for _, proposalID := range proposalIDs {
+		require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	}
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 40)}, spender.pool)
+	require.Equal(t, spend.Amount, spender.paid[addrs[1].String()])
+	require.Contains(t, tags, sdk.MakeTag("poolSpendRecipient", []byte(addrs[1].String())))
+	require.Contains(t, tags, sdk.MakeTag("action", []byte("poolSpendFailed")))
+}
+
+func TestTickPenalizeNonVoters(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	tallyingProcedure.GovernancePenalty = sdk.NewRat(1, 10)
+	keeper.setTallyingProcedure(ctx, tallyingProcedure)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	for _, addr := range addrs[:3] {
+		valCreateMsg := stake.NewMsgCreateValidator(addr, crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 20), dummyDescription)
+		res := stakeHandler(ctx, valCreateMsg)
+		require.True(t, res.IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	// validator 0 votes, validator 1 is revoked and validator 2 doesn't vote
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+	sk.Revoke(ctx, sk.Validator(ctx, addrs[1]).GetPubKey())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+	require.True(sdk.RatEq(t, sdk.NewRat(20), keeper.GetProposal(ctx, proposalID).GetTallyResult().Yes))
+	require.True(sdk.RatEq(t, sdk.NewRat(20), sk.Validator(ctx, addrs[0]).GetPower()))
+	require.True(sdk.RatEq(t, sdk.NewRat(18), sk.Validator(ctx, addrs[2]).GetPower()))
+	require.Contains(t, tags, sdk.MakeTag("nonVotingValidator", []byte(addrs[2].String())))
+	require.NotContains(t, tags, sdk.MakeTag("nonVotingValidator", []byte(addrs[0].String())))
+}
+
+func TestTickNoPenaltyWithoutQuorum(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	tallyingProcedure.GovernancePenalty = sdk.NewRat(1, 10)
+	keeper.setTallyingProcedure(ctx, tallyingProcedure)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	for _, addr := range addrs[:4] {
+		valCreateMsg := stake.NewMsgCreateValidator(addr, crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 20), dummyDescription)
+		res := stakeHandler(ctx, valCreateMsg)
+		require.True(t, res.IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	// a quarter of the bonded power votes, short of the quorum
+	res = govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes))
+	require.True(t, res.IsOK())
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.GetVotingProcedure(ctx).VotingPeriod})
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
+	for _, addr := range addrs[1:4] {
+		require.True(sdk.RatEq(t, sdk.NewRat(20), sk.Validator(ctx, addr).GetPower()))
+		require.NotContains(t, tags, sdk.MakeTag("nonVotingValidator", []byte(addr.String())))
+	}
+}
+
+// send hooks refusing every transfer once refuse is set
+type refusingSendHooks struct {
+	refuse *bool
+}
+
+func (h refusingSendHooks) BeforeSend(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) sdk.Error {
+	if *h.refuse {
+		return sdk.ErrUnauthorized("refused")
+	}
+	return nil
+}
+
+func (h refusingSendHooks) AfterSend(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) {}
+
+func TestEndBlockersWithRefusingSendHooks(t *testing.T) {
+	refuse := false
+	mapp, keeper, sk, addrs, _, _ := getMockAppWithSendHooks(t, 10, refusingSendHooks{&refuse})
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	depositProcedure := keeper.GetDepositProcedure(ctx)
+	depositProcedure.BurnUnmetDeposit = false
+	keeper.setDepositProcedure(ctx, depositProcedure)
+
+	addr0Initial := keeper.ck.GetCoins(ctx, addrs[0])
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 5)}))
+	require.True(t, res.IsOK(), res.Log)
+
+	addr1Initial := keeper.ck.GetCoins(ctx, addrs[1])
+	description := stake.NewDescription("T", "E", "S", "T")
+	res = stakeHandler(ctx, stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 10), description))
+	require.True(t, res.IsOK(), res.Log)
+	res = stakeHandler(ctx, stake.NewMsgBeginUnbonding(addrs[1], addrs[1], sdk.NewRat(10)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the refunded deposit and the matured unbonding are paid out anyway
+	refuse = true
+	blockTime := depositProcedure.MaxDepositPeriod
+	if unbondingTime := sk.GetParams(ctx).UnbondingTime; unbondingTime > blockTime {
+		blockTime = unbondingTime
+	}
+	ctx = ctx.WithBlockHeader(abci.Header{Time: blockTime})
+	require.NotPanics(t, func() {
+		EndBlocker(ctx, keeper)
+		stake.EndBlocker(ctx, sk)
+	})
+	require.Equal(t, addr0Initial, keeper.ck.GetCoins(ctx, addrs[0]))
+	require.Equal(t, addr1Initial, keeper.ck.GetCoins(ctx, addrs[1]))
 }
@@ -20,6 +20,11 @@ const (
 	CodeInvalidProposalType     sdk.CodeType = 8
 	CodeInvalidVote             sdk.CodeType = 9
 	CodeInvalidGenesis          sdk.CodeType = 10
+	CodeInvalidParamChange      sdk.CodeType = 11
+	CodeInvalidUpgradePlan      sdk.CodeType = 12
+	CodeInvalidPoolSpend        sdk.CodeType = 13
+	CodeNotProposer             sdk.CodeType = 14
+	CodeInvalidProposalContent  sdk.CodeType = 15
 )
 
 //----------------------------------------
@@ -65,3 +70,28 @@ func ErrInvalidVote(codespace sdk.CodespaceType, strOption string) sdk.Error {
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
+
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, "invalid parameter change: "+msg)
+}
+
+func ErrUnknownParamSubspace(codespace sdk.CodespaceType, subspace string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, fmt.Sprintf("parameters of %s cannot be changed by governance", subspace))
+}
+
+func ErrInvalidUpgradePlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidUpgradePlan, "invalid upgrade plan: "+msg)
+}
+
+func ErrInvalidPoolSpend(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPoolSpend, "invalid community pool spend: "+msg)
+}
+
+func ErrInvalidProposalContent(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidProposalContent, "invalid proposal content: "+msg)
+}
+
+func ErrNotProposer(codespace sdk.CodespaceType, proposalID int64, address sdk.Address) sdk.Error {
+	bechAddr, _ := sdk.Bech32ifyAcc(address)
+	return sdk.NewError(codespace, CodeNotProposer, fmt.Sprintf("Address %s is not the proposer of proposal %d", bechAddr, proposalID))
+}
@@ -6,12 +6,18 @@ import (
 
 // GenesisState - all staking state that must be provided at genesis
 type GenesisState struct {
-	StartingProposalID int64 `json:"starting_proposalID"`
+	StartingProposalID int64             `json:"starting_proposalID"`
+	DepositProcedure   DepositProcedure  `json:"deposit_procedure"`
+	VotingProcedure    VotingProcedure   `json:"voting_procedure"`
+	TallyingProcedure  TallyingProcedure `json:"tallying_procedure"`
 }
 
-func NewGenesisState(startingProposalID int64) GenesisState {
+func NewGenesisState(startingProposalID int64, dp DepositProcedure, vp VotingProcedure, tp TallyingProcedure) GenesisState {
 	return GenesisState{
 		StartingProposalID: startingProposalID,
+		DepositProcedure:   dp,
+		VotingProcedure:    vp,
+		TallyingProcedure:  tp,
 	}
 }
 
@@ -19,6 +25,9 @@ func NewGenesisState(startingProposalID int64) GenesisState {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		StartingProposalID: 1,
+		DepositProcedure:   DefaultDepositProcedure(),
+		VotingProcedure:    DefaultVotingProcedure(),
+		TallyingProcedure:  DefaultTallyingProcedure(),
 	}
 }
 
@@ -29,6 +38,9 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 		// TODO: Handle this with #870
 		panic(err)
 	}
+	k.setDepositProcedure(ctx, data.DepositProcedure)
+	k.setVotingProcedure(ctx, data.VotingProcedure)
+	k.setTallyingProcedure(ctx, data.TallyingProcedure)
 }
 
 // WriteGenesis - output genesis parameters
@@ -37,5 +49,8 @@ func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
 
 	return GenesisState{
 		initalProposalID,
+		k.GetDepositProcedure(ctx),
+		k.GetVotingProcedure(ctx),
+		k.GetTallyingProcedure(ctx),
 	}
 }
@@ -1,7 +1,10 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // Handle all "gov" type messages.
@@ -14,6 +17,10 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
+		case MsgCancelProposal:
+			return handleMsgCancelProposal(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized gov msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -23,7 +30,31 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch content := msg.Content.(type) {
+	case ParamChangeContent:
+		err := keeper.CheckParamChanges(content.Changes)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, content.Changes)
+	case SoftwareUpgradeContent:
+		err := keeper.CheckUpgradePlan(ctx, content.Plan)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, content.Plan)
+	case CommunityPoolSpend:
+		err := keeper.CheckPoolSpend()
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewCommunityPoolSpendProposal(ctx, msg.Title, msg.Description, content)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
+	proposal.SetProposer(msg.Proposer)
+	keeper.SetProposal(ctx, proposal)
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -39,7 +70,7 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 	)
 
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		tags = tags.AppendTag("votingPeriodStart", proposalIDBytes)
 	}
 
 	return sdk.Result{
@@ -65,7 +96,7 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 	)
 
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		tags = tags.AppendTag("votingPeriodStart", proposalIDBytes)
 	}
 
 	return sdk.Result{
@@ -73,18 +104,45 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 	}
 }
 
-func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
+func handleMsgCancelProposal(ctx sdk.Context, keeper Keeper, msg MsgCancelProposal) sdk.Result {
 
-	err := keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	err := keeper.CancelProposal(ctx, msg.ProposalID, msg.Proposer)
 	if err != nil {
 		return err.Result()
 	}
 
 	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
 
+	tags := sdk.NewTags(
+		"action", []byte("cancelProposal"),
+		"proposer", []byte(msg.Proposer.String()),
+		"proposalId", proposalIDBytes,
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
+	return handleVote(ctx, keeper, msg.ProposalID, msg.Voter, NewNonSplitVoteOption(msg.Option))
+}
+
+func handleMsgVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgVoteWeighted) sdk.Result {
+	return handleVote(ctx, keeper, msg.ProposalID, msg.Voter, msg.Options)
+}
+
+func handleVote(ctx sdk.Context, keeper Keeper, proposalID int64, voter sdk.Address, options []WeightedVoteOption) sdk.Result {
+
+	err := keeper.AddVote(ctx, proposalID, voter, options)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposalID)
+
 	tags := sdk.NewTags(
 		"action", []byte("vote"),
-		"voter", []byte(msg.Voter.String()),
+		"voter", []byte(voter.String()),
 		"proposalId", proposalIDBytes,
 	)
 	return sdk.Result{
@@ -93,69 +151,153 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 }
 
 // Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags, nonVotingVals []sdk.Address) {
+func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags) {
 
 	tags = sdk.NewTags()
 
-	// Delete proposals that haven't met minDeposit
+	// Delete proposals that haven't met minDeposit, burning or refunding their deposits
 	for shouldPopInactiveProposalQueue(ctx, keeper) {
 		inactiveProposal := keeper.InactiveProposalQueuePop(ctx)
 		if inactiveProposal.GetStatus() == StatusDepositPeriod {
 			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
+			if keeper.GetDepositProcedure(ctx).BurnUnmetDeposit {
+				keeper.DeleteDeposits(ctx, inactiveProposal.GetProposalID())
+			} else {
+				keeper.RefundDeposits(ctx, inactiveProposal.GetProposalID())
+			}
 			keeper.DeleteProposal(ctx, inactiveProposal)
-			tags.AppendTag("action", []byte("proposalDropped"))
-			tags.AppendTag("proposalId", proposalIDBytes)
+			tags = tags.AppendTag("action", []byte("proposalDropped"))
+			tags = tags.AppendTag("proposalId", proposalIDBytes)
 		}
 	}
 
-	var passes bool
+	var passes, vetoed bool
 
 	// Check if earliest Active Proposal ended voting period yet
 	for shouldPopActiveProposalQueue(ctx, keeper) {
 		activeProposal := keeper.ActiveProposalQueuePop(ctx)
 
-		if ctx.BlockHeight() >= activeProposal.GetVotingStartBlock()+keeper.GetVotingProcedure().VotingPeriod {
-			passes, nonVotingVals = tally(ctx, keeper, activeProposal)
+		if ctx.BlockHeader().Time >= activeProposal.GetVotingStartTime()+keeper.GetVotingProcedure(ctx).VotingPeriod {
+			var tallyResults TallyResult
+			var nonVoting []sdk.Address
+			passes, vetoed, tallyResults, nonVoting = tally(ctx, keeper, activeProposal)
+			activeProposal.SetTallyResult(tallyResults)
 			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
+			tags = tags.AppendTags(penalizeNonVoters(ctx, keeper, nonVoting))
 			if passes {
 				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 				activeProposal.SetStatus(StatusPassed)
-				tags.AppendTag("action", []byte("proposalPassed"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				tags = tags.AppendTag("action", []byte("proposalPassed"))
+				tags = tags.AppendTag("proposalId", proposalIDBytes)
+				switch proposal := activeProposal.(type) {
+				case *ParameterChangeProposal:
+					tags = tags.AppendTags(applyParamChanges(ctx, keeper, proposal, proposalIDBytes))
+				case *SoftwareUpgradeProposal:
+					tags = tags.AppendTags(scheduleUpgrade(ctx, keeper, proposal, proposalIDBytes))
+				case *CommunityPoolSpendProposal:
+					tags = tags.AppendTags(payPoolSpend(ctx, keeper, proposal, proposalIDBytes))
+				}
 			} else {
-				keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
+				// Deposits of vetoed proposals are burned, other rejected proposals are refunded
+				if vetoed && keeper.GetDepositProcedure(ctx).BurnVetoed {
+					keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
+				} else {
+					keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
+				}
 				activeProposal.SetStatus(StatusRejected)
-				tags.AppendTag("action", []byte("proposalRejected"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				tags = tags.AppendTag("action", []byte("proposalRejected"))
+				tags = tags.AppendTag("proposalId", proposalIDBytes)
 			}
 
 			keeper.SetProposal(ctx, activeProposal)
 		}
 	}
 
-	return tags, nonVotingVals
+	return tags
+}
+
+// slash the bonded validators which did not vote on a completed proposal by
+// the governance penalty
+func penalizeNonVoters(ctx sdk.Context, keeper Keeper, nonVoting []sdk.Address) sdk.Tags {
+	tags := sdk.NewTags()
+	penalty := keeper.GetTallyingProcedure(ctx).GovernancePenalty
+	if penalty.IsZero() {
+		return tags
+	}
+	for _, address := range nonVoting {
+		validator := keeper.vs.Validator(ctx, address)
+		if validator == nil || validator.GetRevoked() {
+			continue
+		}
+		keeper.vs.Slash(ctx, validator.GetPubKey(), ctx.BlockHeight(), validator.GetPower().RoundInt64(), penalty)
+		tags = tags.AppendTag("nonVotingValidator", []byte(address.String()))
+	}
+	return tags
+}
+
+// apply the changes of a passed proposal, a failing change leaves the
+// parameters untouched. The changes are recorded in the parameter history
+// with the ID of the proposal.
+func applyParamChanges(ctx sdk.Context, keeper Keeper, proposal *ParameterChangeProposal, proposalIDBytes []byte) sdk.Tags {
+	err := keeper.ApplyParamChanges(params.WithProposalID(ctx, proposal.GetProposalID()), proposal.Changes)
+	if err != nil {
+		ctx.Logger().With("module", "x/gov").Error(
+			fmt.Sprintf("parameter changes of proposal %d failed: %v", proposal.GetProposalID(), err.ABCILog()))
+		return sdk.NewTags("action", []byte("paramChangeFailed"), "proposalId", proposalIDBytes)
+	}
+	tags := sdk.NewTags()
+	for _, change := range proposal.Changes {
+		tags = tags.AppendTag("paramChange", []byte(change.Subspace+"/"+change.Key))
+	}
+	return tags
+}
+
+// schedule the plan of a passed software upgrade proposal
+func scheduleUpgrade(ctx sdk.Context, keeper Keeper, proposal *SoftwareUpgradeProposal, proposalIDBytes []byte) sdk.Tags {
+	err := keeper.CheckUpgradePlan(ctx, proposal.Plan)
+	if err == nil {
+		err = keeper.upgradeScheduler.ScheduleUpgrade(ctx, proposal.Plan)
+	}
+	if err != nil {
+		ctx.Logger().With("module", "x/gov").Error(
+			fmt.Sprintf("upgrade of proposal %d failed: %v", proposal.GetProposalID(), err.ABCILog()))
+		return sdk.NewTags("action", []byte("upgradeFailed"), "proposalId", proposalIDBytes)
+	}
+	return sdk.NewTags("upgradeScheduled", []byte(proposal.Plan.Name))
+}
+
+// pay the spend of a passed community pool spend proposal
+func payPoolSpend(ctx sdk.Context, keeper Keeper, proposal *CommunityPoolSpendProposal, proposalIDBytes []byte) sdk.Tags {
+	err := keeper.PayPoolSpend(ctx, proposal.Spend)
+	if err != nil {
+		ctx.Logger().With("module", "x/gov").Error(
+			fmt.Sprintf("community pool spend of proposal %d failed: %v", proposal.GetProposalID(), err.ABCILog()))
+		return sdk.NewTags("action", []byte("poolSpendFailed"), "proposalId", proposalIDBytes)
+	}
+	return sdk.NewTags("poolSpendRecipient", []byte(proposal.Spend.Recipient.String()))
 }
+
 func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	depositProcedure := keeper.GetDepositProcedure()
+	depositProcedure := keeper.GetDepositProcedure(ctx)
 	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
 
 	if peekProposal == nil {
 		return false
 	} else if peekProposal.GetStatus() != StatusDepositPeriod {
 		return true
-	} else if ctx.BlockHeight() >= peekProposal.GetSubmitBlock()+depositProcedure.MaxDepositPeriod {
+	} else if ctx.BlockHeader().Time >= peekProposal.GetSubmitTime()+depositProcedure.MaxDepositPeriod {
 		return true
 	}
 	return false
 }
 
 func shouldPopActiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	votingProcedure := keeper.GetVotingProcedure()
+	votingProcedure := keeper.GetVotingProcedure(ctx)
 	peekProposal := keeper.ActiveProposalQueuePeek(ctx)
 
 	if peekProposal == nil {
 		return false
-	} else if ctx.BlockHeight() >= peekProposal.GetVotingStartBlock()+votingProcedure.VotingPeriod {
+	} else if ctx.BlockHeader().Time >= peekProposal.GetVotingStartTime()+votingProcedure.VotingPeriod {
 		return true
 	}
 	return false
@@ -1,16 +1,27 @@
 package gov
 
 import (
+	"bytes"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
+// ModuleName is the name of the module account escrowing the deposits
+const ModuleName = "gov"
+
 // Governance Keeper
 type Keeper struct {
 	// The reference to the CoinKeeper to modify balances
 	ck bank.Keeper
 
+	// The subspace of the procedures in the parameter store
+	paramSpace params.Subspace
+
 	// The ValidatorSet to get information about validators
 	vs sdk.ValidatorSet
 
@@ -25,20 +36,66 @@ type Keeper struct {
 
 	// Reserved codespace
 	codespace sdk.CodespaceType
+
+	// The handlers of the parameter changes, keyed by subspace
+	paramChangeHandlers map[string]ParamChangeHandler
+
+	// The upgrade keeper scheduling the plans of the software upgrades
+	upgradeScheduler UpgradeScheduler
+
+	// The distribution keeper paying the community pool spends
+	poolSpender CommunityPoolSpender
+}
+
+// ParamChangeHandler checks and applies the changes of the parameters of a
+// subspace proposed by a ParameterChangeProposal
+type ParamChangeHandler interface {
+	CheckParamChange(key string, value string) sdk.Error
+	ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error
 }
 
 // NewGovernanceMapper returns a mapper that uses go-wire to (binary) encode and decode gov types.
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, paramSpace params.Subspace, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
-		storeKey:  key,
-		ck:        ck,
-		ds:        ds,
-		vs:        ds.GetValidatorSet(),
-		cdc:       cdc,
-		codespace: codespace,
+		storeKey:   key,
+		ck:         ck,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		ds:         ds,
+		vs:         ds.GetValidatorSet(),
+		cdc:        cdc,
+		codespace:  codespace,
 	}
 }
 
+// UpgradeScheduler schedules the plan of a passed SoftwareUpgradeProposal
+type UpgradeScheduler interface {
+	ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) sdk.Error
+}
+
+// CommunityPoolSpender pays the spend of a passed CommunityPoolSpendProposal
+type CommunityPoolSpender interface {
+	DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, recipient sdk.Address) sdk.Error
+}
+
+// WithParamChangeHandlers returns a keeper able to change the parameters of
+// the given subspaces
+func (keeper Keeper) WithParamChangeHandlers(handlers map[string]ParamChangeHandler) Keeper {
+	keeper.paramChangeHandlers = handlers
+	return keeper
+}
+
+// WithUpgradeScheduler returns a keeper able to schedule software upgrades
+func (keeper Keeper) WithUpgradeScheduler(scheduler UpgradeScheduler) Keeper {
+	keeper.upgradeScheduler = scheduler
+	return keeper
+}
+
+// WithCommunityPoolSpender returns a keeper able to pay community pool spends
+func (keeper Keeper) WithCommunityPoolSpender(spender CommunityPoolSpender) Keeper {
+	keeper.poolSpender = spender
+	return keeper
+}
+
 // Returns the go-wire codec.
 func (keeper Keeper) WireCodec() *wire.Codec {
 	return keeper.cdc
@@ -53,21 +110,143 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	if err != nil {
 		return nil
 	}
-	var proposal Proposal = &TextProposal{
-		ProposalID:       proposalID,
-		Title:            title,
-		Description:      description,
-		ProposalType:     proposalType,
-		Status:           StatusDepositPeriod,
-		TotalDeposit:     sdk.Coins{},
-		SubmitBlock:      ctx.BlockHeight(),
-		VotingStartBlock: -1, // TODO: Make Time
+	textProposal := keeper.newTextProposal(ctx, proposalID, title, description, proposalType)
+	var proposal Proposal = &textProposal
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a proposal applying the parameter changes once passed
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeParameterChange),
+		Changes:      changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a proposal scheduling the upgrade plan once passed
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeSoftwareUpgrade),
+		Plan:         plan,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a proposal paying the spend once passed
+func (keeper Keeper) NewCommunityPoolSpendProposal(ctx sdk.Context, title string, description string, spend CommunityPoolSpend) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &CommunityPoolSpendProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeCommunityPoolSpend),
+		Spend:        spend,
 	}
 	keeper.SetProposal(ctx, proposal)
 	keeper.InactiveProposalQueuePush(ctx, proposal)
 	return proposal
 }
 
+func (keeper Keeper) newTextProposal(ctx sdk.Context, proposalID int64, title string, description string, proposalType byte) TextProposal {
+	return TextProposal{
+		ProposalID:      proposalID,
+		Title:           title,
+		Description:     description,
+		ProposalType:    proposalType,
+		Status:          StatusDepositPeriod,
+		TotalDeposit:    sdk.Coins{},
+		SubmitTime:      ctx.BlockHeader().Time,
+		VotingStartTime: -1,
+		TallyResult:     EmptyTallyResult(),
+	}
+}
+
+// CheckUpgradePlan checks that a software upgrade can be scheduled at the
+// height of the plan
+func (keeper Keeper) CheckUpgradePlan(ctx sdk.Context, plan upgrade.Plan) sdk.Error {
+	if keeper.upgradeScheduler == nil {
+		return ErrInvalidUpgradePlan(keeper.codespace, "software upgrades are not supported")
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return ErrInvalidUpgradePlan(keeper.codespace, fmt.Sprintf("height %d has already passed", plan.Height))
+	}
+	return nil
+}
+
+// CheckPoolSpend checks that community pool spends can be paid, the balance
+// of the pool is only checked once the proposal passes
+func (keeper Keeper) CheckPoolSpend() sdk.Error {
+	if keeper.poolSpender == nil {
+		return ErrInvalidPoolSpend(keeper.codespace, "community pool spends are not supported")
+	}
+	return nil
+}
+
+// PayPoolSpend pays the spend of a passed community pool spend proposal, the
+// pool is left untouched if the payment fails
+func (keeper Keeper) PayPoolSpend(ctx sdk.Context, spend CommunityPoolSpend) sdk.Error {
+	err := keeper.CheckPoolSpend()
+	if err != nil {
+		return err
+	}
+	cacheCtx, write := ctx.CacheContext()
+	err = keeper.poolSpender.DistributeFromFeePool(cacheCtx, spend.Amount, spend.Recipient)
+	if err != nil {
+		return err
+	}
+	write()
+	return nil
+}
+
+// CheckParamChanges checks the changes of a parameter change proposal
+// against the current parameter declarations
+func (keeper Keeper) CheckParamChanges(changes []ParamChange) sdk.Error {
+	for _, change := range changes {
+		handler, ok := keeper.paramChangeHandlers[change.Subspace]
+		if !ok {
+			return ErrUnknownParamSubspace(keeper.codespace, change.Subspace)
+		}
+		err := handler.CheckParamChange(change.Key, change.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyParamChanges applies the changes of a passed parameter change proposal.
+// The changes are written only if all of them succeed.
+func (keeper Keeper) ApplyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	cacheCtx, write := ctx.CacheContext()
+	for _, change := range changes {
+		handler, ok := keeper.paramChangeHandlers[change.Subspace]
+		if !ok {
+			return ErrUnknownParamSubspace(keeper.codespace, change.Subspace)
+		}
+		err := handler.ApplyParamChange(cacheCtx, change.Key, change.Value)
+		if err != nil {
+			return err
+		}
+	}
+	write()
+	return nil
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -118,8 +297,29 @@ func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64, err sd
 	return proposalID, nil
 }
 
+// CancelProposal lets the proposer withdraw a proposal still in its deposit
+// period, burning the CancelBurnRate proportion of the deposits and refunding
+// the rest
+func (keeper Keeper) CancelProposal(ctx sdk.Context, proposalID int64, proposer sdk.Address) sdk.Error {
+	proposal := keeper.GetProposal(ctx, proposalID)
+	if proposal == nil {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetStatus() != StatusDepositPeriod {
+		return ErrAlreadyActiveProposal(keeper.codespace, proposalID)
+	}
+	if !bytes.Equal(proposal.GetProposer(), proposer) {
+		return ErrNotProposer(keeper.codespace, proposalID, proposer)
+	}
+
+	keeper.settleDeposits(ctx, proposalID, keeper.GetDepositProcedure(ctx).CancelBurnRate)
+	keeper.inactiveProposalQueueRemove(ctx, proposalID)
+	keeper.DeleteProposal(ctx, proposal)
+	return nil
+}
+
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
-	proposal.SetVotingStartBlock(ctx.BlockHeight())
+	proposal.SetVotingStartTime(ctx.BlockHeader().Time)
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 	keeper.ActiveProposalQueuePush(ctx, proposal)
@@ -128,35 +328,45 @@ func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 // =====================================================
 // Procedures
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetDepositProcedure() DepositProcedure {
-	return DepositProcedure{
-		MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
-		MaxDepositPeriod: 200,
-	}
+// Returns the current Deposit Procedure from the global param store
+func (keeper Keeper) GetDepositProcedure(ctx sdk.Context) (depositProcedure DepositProcedure) {
+	keeper.paramSpace.Get(ctx, KeyDepositProcedure, &depositProcedure)
+	return
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetVotingProcedure() VotingProcedure {
-	return VotingProcedure{
-		VotingPeriod: 200,
-	}
+// Returns the current Voting Procedure from the global param store
+func (keeper Keeper) GetVotingProcedure(ctx sdk.Context) (votingProcedure VotingProcedure) {
+	keeper.paramSpace.Get(ctx, KeyVotingProcedure, &votingProcedure)
+	return
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetTallyingProcedure() TallyingProcedure {
-	return TallyingProcedure{
-		Threshold:         sdk.NewRat(1, 2),
-		Veto:              sdk.NewRat(1, 3),
-		GovernancePenalty: sdk.NewRat(1, 100),
-	}
+// Returns the current Tallying Procedure from the global param store
+func (keeper Keeper) GetTallyingProcedure(ctx sdk.Context) (tallyingProcedure TallyingProcedure) {
+	keeper.paramSpace.Get(ctx, KeyTallyingProcedure, &tallyingProcedure)
+	return
+}
+
+// nolint
+func (keeper Keeper) setDepositProcedure(ctx sdk.Context, depositProcedure DepositProcedure) {
+	keeper.paramSpace.Set(ctx, KeyDepositProcedure, depositProcedure)
+}
+
+// nolint
+func (keeper Keeper) setVotingProcedure(ctx sdk.Context, votingProcedure VotingProcedure) {
+	keeper.paramSpace.Set(ctx, KeyVotingProcedure, votingProcedure)
+}
+
+// nolint
+func (keeper Keeper) setTallyingProcedure(ctx sdk.Context, tallyingProcedure TallyingProcedure) {
+	keeper.paramSpace.Set(ctx, KeyTallyingProcedure, tallyingProcedure)
 }
 
 // =====================================================
 // Votes
 
-// Adds a vote on a specific proposal
-func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, option VoteOption) sdk.Error {
+// Adds a vote on a specific proposal, splitting the voting power of the voter
+// across the weighted options
+func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, options []WeightedVoteOption) sdk.Error {
 	proposal := keeper.GetProposal(ctx, proposalID)
 	if proposal == nil {
 		return ErrUnknownProposal(keeper.codespace, proposalID)
@@ -165,14 +375,14 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ad
 		return ErrInactiveProposal(keeper.codespace, proposalID)
 	}
 
-	if option != OptionYes && option != OptionAbstain && option != OptionNo && option != OptionNoWithVeto {
-		return ErrInvalidVote(keeper.codespace, VoteOptionToString(option))
+	if !validWeightedVoteOptions(options) {
+		return ErrInvalidVote(keeper.codespace, WeightedVoteOptionsToString(options))
 	}
 
 	vote := Vote{
 		ProposalID: proposalID,
 		Voter:      voterAddr,
-		Option:     option,
+		Options:    options,
 	}
 	keeper.setVote(ctx, proposalID, voterAddr, vote)
 
@@ -243,8 +453,8 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 		return ErrAlreadyFinishedProposal(keeper.codespace, proposalID), false
 	}
 
-	// Subtract coins from depositer's account
-	_, _, err := keeper.ck.SubtractCoins(ctx, depositerAddr, depositAmount)
+	// Escrow the coins in the module account
+	_, err := keeper.ck.SendCoinsFromAccountToModule(ctx, depositerAddr, ModuleName, depositAmount)
 	if err != nil {
 		return err, false
 	}
@@ -256,7 +466,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	// Check if deposit tipped proposal into voting period
 	// Active voting period if so
 	activatedVotingPeriod := false
-	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure().MinDeposit) {
+	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure(ctx).MinDeposit) {
 		keeper.activateVotingPeriod(ctx, proposal)
 		activatedVotingPeriod = true
 	}
@@ -282,34 +492,68 @@ func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator
 
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
+	keeper.settleDeposits(ctx, proposalID, sdk.ZeroRat())
+}
+
+// Deletes all the deposits on a specific proposal without refunding them,
+// burning the deposited coins
+func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
+	keeper.settleDeposits(ctx, proposalID, sdk.OneRat())
+}
+
+// Deletes all the deposits on a specific proposal, burning the burnRate
+// proportion of each deposit and refunding the rest to its depositer
+func (keeper Keeper) settleDeposits(ctx sdk.Context, proposalID int64, burnRate sdk.Rat) {
 	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 
+	burned := sdk.Coins{}
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
 
-		_, _, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
-		if err != nil {
-			panic("should not happen")
+		burn := sdk.Coins{}
+		for _, coin := range deposit.Amount {
+			amount := sdk.NewRatFromInt(coin.Amount).Mul(burnRate).RoundInt()
+			burn = burn.Plus(sdk.Coins{sdk.Coin{Denom: coin.Denom, Amount: amount}})
+		}
+		burned = burned.Plus(burn)
+
+		refund := deposit.Amount.Minus(burn)
+		if !refund.IsZero() {
+			_, err := keeper.ck.SendCoinsFromModuleToAccount(ctx, ModuleName, deposit.Depositer, refund)
+			if err != nil {
+				panic("should not happen")
+			}
 		}
 
 		store.Delete(depositsIterator.Key())
 	}
 
 	depositsIterator.Close()
+
+	if !burned.IsZero() {
+		_, err := keeper.ck.BurnModuleCoins(ctx, ModuleName, burned)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
-// Deletes all the deposits on a specific proposal without refunding them
-func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
+// Gets the coins of all the deposits held by the governance module
+func (keeper Keeper) GetDepositedCoins(ctx sdk.Context) sdk.Coins {
 	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+	depositsIterator := sdk.KVStorePrefixIterator(store, KeyDepositsPrefix)
 
+	deposited := sdk.Coins{}
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		store.Delete(depositsIterator.Key())
+		deposit := &Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+		deposited = deposited.Plus(deposit.Amount)
 	}
 
 	depositsIterator.Close()
+	return deposited
 }
 
 // =====================================================
@@ -405,3 +649,14 @@ func (keeper Keeper) InactiveProposalQueuePush(ctx sdk.Context, proposal Proposa
 	proposalQueue := append(keeper.getInactiveProposalQueue(ctx), proposal.GetProposalID())
 	keeper.setInactiveProposalQueue(ctx, proposalQueue)
 }
+
+// Remove a proposalID from anywhere in the InactiveProposalQueue
+func (keeper Keeper) inactiveProposalQueueRemove(ctx sdk.Context, proposalID int64) {
+	proposalQueue := ProposalQueue{}
+	for _, id := range keeper.getInactiveProposalQueue(ctx) {
+		if id != proposalID {
+			proposalQueue = append(proposalQueue, id)
+		}
+	}
+	keeper.setInactiveProposalQueue(ctx, proposalQueue)
+}
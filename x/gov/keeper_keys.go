@@ -13,6 +13,7 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyDepositsPrefix        = []byte("deposits:")
 )
 
 // Key for getting a specific proposal from the store
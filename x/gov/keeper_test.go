@@ -45,12 +45,12 @@ func TestActivateVotingPeriod(t *testing.T) {
 
 	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
 
-	require.Equal(t, int64(-1), proposal.GetVotingStartBlock())
+	require.Equal(t, int64(-1), proposal.GetVotingStartTime())
 	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
 
 	keeper.activateVotingPeriod(ctx, proposal)
 
-	require.Equal(t, proposal.GetVotingStartBlock(), ctx.BlockHeight())
+	require.Equal(t, proposal.GetVotingStartTime(), ctx.BlockHeader().Time)
 	require.Equal(t, proposal.GetProposalID(), keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
 }
 
@@ -77,7 +77,7 @@ func TestDeposits(t *testing.T) {
 	// Check no deposits at beginning
 	deposit, found := keeper.GetDeposit(ctx, proposalID, addrs[1])
 	require.False(t, found)
-	require.Equal(t, keeper.GetProposal(ctx, proposalID).GetVotingStartBlock(), int64(-1))
+	require.Equal(t, keeper.GetProposal(ctx, proposalID).GetVotingStartTime(), int64(-1))
 	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
 
 	// Check first deposit
@@ -112,9 +112,10 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, fourSteak, deposit.Amount)
 	require.Equal(t, fourSteak.Plus(fiveSteak).Plus(fourSteak), keeper.GetProposal(ctx, proposalID).GetTotalDeposit())
 	require.Equal(t, addr1Initial.Minus(fourSteak), keeper.ck.GetCoins(ctx, addrs[1]))
+	require.Equal(t, keeper.GetDepositedCoins(ctx), keeper.ck.GetModuleCoins(ctx, ModuleName))
 
 	// Check that proposal moved to voting period
-	require.Equal(t, ctx.BlockHeight(), keeper.GetProposal(ctx, proposalID).GetVotingStartBlock())
+	require.Equal(t, ctx.BlockHeader().Time, keeper.GetProposal(ctx, proposalID).GetVotingStartTime())
 	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
 	require.Equal(t, proposalID, keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
 
@@ -140,7 +141,51 @@ func TestDeposits(t *testing.T) {
 	require.False(t, found)
 	require.Equal(t, addr0Initial, keeper.ck.GetCoins(ctx, addrs[0]))
 	require.Equal(t, addr1Initial, keeper.ck.GetCoins(ctx, addrs[1]))
+	require.True(t, keeper.ck.GetModuleCoins(ctx, ModuleName).IsZero())
+}
+
+func TestCancelProposal(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+
+	addr0Initial := keeper.ck.GetCoins(ctx, addrs[0])
+	addr1Initial := keeper.ck.GetCoins(ctx, addrs[1])
+
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 4)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, addrs[0], keeper.GetProposal(ctx, proposalID).GetProposer())
+	err, _ := keeper.AddDeposit(ctx, proposalID, addrs[1], sdk.Coins{sdk.NewCoin("steak", 3)})
+	require.Nil(t, err)
 
+	// only the proposer can cancel
+	err = keeper.CancelProposal(ctx, proposalID, addrs[1])
+	require.NotNil(t, err)
+	require.Equal(t, CodeNotProposer, err.Code())
+	err = keeper.CancelProposal(ctx, proposalID+1, addrs[0])
+	require.NotNil(t, err)
+
+	// half of each deposit is burned with bankers rounding, the rest is refunded
+	res = govHandler(ctx, NewMsgCancelProposal(addrs[0], proposalID))
+	require.True(t, res.IsOK())
+	require.Nil(t, keeper.GetProposal(ctx, proposalID))
+	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
+	require.Equal(t, addr0Initial.Minus(sdk.Coins{sdk.NewCoin("steak", 2)}), keeper.ck.GetCoins(ctx, addrs[0]))
+	require.Equal(t, addr1Initial.Minus(sdk.Coins{sdk.NewCoin("steak", 2)}), keeper.ck.GetCoins(ctx, addrs[1]))
+	require.True(t, keeper.GetDepositedCoins(ctx).IsZero())
+	require.True(t, keeper.ck.GetModuleCoins(ctx, ModuleName).IsZero())
+
+	// proposals in their voting period can't be cancelled
+	res = govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+	err = keeper.CancelProposal(ctx, proposalID, addrs[0])
+	require.NotNil(t, err)
+	require.Equal(t, CodeAlreadyActiveProposal, err.Code())
 }
 
 func TestVotes(t *testing.T) {
@@ -156,28 +201,43 @@ func TestVotes(t *testing.T) {
 	keeper.SetProposal(ctx, proposal)
 
 	// Test first vote
-	keeper.AddVote(ctx, proposalID, addrs[0], OptionAbstain)
+	keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionAbstain))
 	vote, found := keeper.GetVote(ctx, proposalID, addrs[0])
 	require.True(t, found)
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionAbstain, vote.Option)
+	require.Equal(t, OptionAbstain, vote.Options[0].Option)
 
 	// Test change of vote
-	keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	vote, found = keeper.GetVote(ctx, proposalID, addrs[0])
 	require.True(t, found)
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionYes, vote.Option)
+	require.Equal(t, OptionYes, vote.Options[0].Option)
 
 	// Test second vote
-	keeper.AddVote(ctx, proposalID, addrs[1], OptionNoWithVeto)
+	keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionNoWithVeto))
 	vote, found = keeper.GetVote(ctx, proposalID, addrs[1])
 	require.True(t, found)
 	require.Equal(t, addrs[1], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionNoWithVeto, vote.Option)
+	require.Equal(t, OptionNoWithVeto, vote.Options[0].Option)
+
+	// Test split votes, the weights must sum to one
+	err := keeper.AddVote(ctx, proposalID, addrs[1], []WeightedVoteOption{{OptionYes, sdk.NewRat(1, 2)}, {OptionNo, sdk.NewRat(1, 3)}})
+	require.NotNil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], []WeightedVoteOption{{OptionYes, sdk.NewRat(1, 2)}, {OptionYes, sdk.NewRat(1, 2)}})
+	require.NotNil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], []WeightedVoteOption{{OptionYes, sdk.NewRat(3, 4)}, {OptionNoWithVeto, sdk.NewRat(1, 4)}})
+	require.Nil(t, err)
+	vote, found = keeper.GetVote(ctx, proposalID, addrs[1])
+	require.True(t, found)
+	require.Equal(t, 2, len(vote.Options))
+	require.Equal(t, OptionYes, vote.Options[0].Option)
+	require.True(sdk.RatEq(t, sdk.NewRat(3, 4), vote.Options[0].Weight))
+	require.Equal(t, OptionNoWithVeto, vote.Options[1].Option)
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 4), vote.Options[1].Weight))
 
 	// Test vote iterator
 	votesIterator := keeper.GetVotes(ctx, proposalID)
@@ -186,14 +246,14 @@ func TestVotes(t *testing.T) {
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionYes, vote.Option)
+	require.Equal(t, OptionYes, vote.Options[0].Option)
 	votesIterator.Next()
 	require.True(t, votesIterator.Valid())
 	keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[1], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionNoWithVeto, vote.Option)
+	require.Equal(t, OptionYes, vote.Options[0].Option)
 	votesIterator.Next()
 	require.False(t, votesIterator.Valid())
 }
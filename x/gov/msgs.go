@@ -12,11 +12,12 @@ const MsgType = "gov"
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string       //  Title of the proposal
-	Description    string       //  Description of the proposal
-	ProposalType   ProposalKind //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.Address  //  Address of the proposer
-	InitialDeposit sdk.Coins    //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string          //  Title of the proposal
+	Description    string          //  Description of the proposal
+	ProposalType   ProposalKind    //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.Address     //  Address of the proposer
+	InitialDeposit sdk.Coins       //  Initial deposit paid by sender. Must be strictly positive.
+	Content        ProposalContent //  Content specific to the type of proposal, nil for a text proposal
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -29,6 +30,13 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+// NewMsgSubmitProposalWithContent submits a proposal of the type of its content
+func NewMsgSubmitProposalWithContent(title string, description string, content ProposalContent, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+	msg := NewMsgSubmitProposal(title, description, content.ProposalType(), proposer, initialDeposit)
+	msg.Content = content
+	return msg
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 
@@ -52,7 +60,17 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
-	return nil
+	if msg.Content == nil {
+		if msg.ProposalType != ProposalTypeText {
+			return ErrInvalidProposalContent(DefaultCodespace, "only text proposals carry no content")
+		}
+		return nil
+	}
+	if msg.Content.ProposalType() != msg.ProposalType {
+		return ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("%s proposal with the content of a %s proposal",
+			ProposalTypeToString(msg.ProposalType), ProposalTypeToString(msg.Content.ProposalType())))
+	}
+	return msg.Content.ValidateBasic()
 }
 
 func (msg MsgSubmitProposal) String() string {
@@ -67,17 +85,19 @@ func (msg MsgSubmitProposal) Get(key interface{}) (value interface{}) {
 // Implements Msg.
 func (msg MsgSubmitProposal) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(struct {
-		Title          string    `json:"title"`
-		Description    string    `json:"description"`
-		ProposalType   string    `json:"proposal_type"`
-		Proposer       string    `json:"proposer"`
-		InitialDeposit sdk.Coins `json:"deposit"`
+		Title          string          `json:"title"`
+		Description    string          `json:"description"`
+		ProposalType   string          `json:"proposal_type"`
+		Proposer       string          `json:"proposer"`
+		InitialDeposit sdk.Coins       `json:"deposit"`
+		Content        ProposalContent `json:"content,omitempty"`
 	}{
 		Title:          msg.Title,
 		Description:    msg.Description,
 		ProposalType:   ProposalTypeToString(msg.ProposalType),
 		Proposer:       sdk.MustBech32ifyVal(msg.Proposer),
 		InitialDeposit: msg.InitialDeposit,
+		Content:        msg.Content,
 	})
 	if err != nil {
 		panic(err)
@@ -220,3 +240,124 @@ func (msg MsgVote) GetSignBytes() []byte {
 func (msg MsgVote) GetSigners() []sdk.Address {
 	return []sdk.Address{msg.Voter}
 }
+
+//-----------------------------------------------------------
+// MsgVoteWeighted
+type MsgVoteWeighted struct {
+	ProposalID int64                //  proposalID of the proposal
+	Voter      sdk.Address          //  address of the voter
+	Options    []WeightedVoteOption //  options from OptionSet chosen by the voter, with weights summing to one
+}
+
+func NewMsgVoteWeighted(voter sdk.Address, proposalID int64, options []WeightedVoteOption) MsgVoteWeighted {
+	return MsgVoteWeighted{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.Voter.Bytes()) == 0 {
+		return sdk.ErrInvalidAddress(msg.Voter.String())
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	if !validWeightedVoteOptions(msg.Options) {
+		return ErrInvalidVote(DefaultCodespace, WeightedVoteOptionsToString(msg.Options))
+	}
+	return nil
+}
+
+func (msg MsgVoteWeighted) String() string {
+	return fmt.Sprintf("MsgVoteWeighted{%v - %v}", msg.ProposalID, WeightedVoteOptionsToString(msg.Options))
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ProposalID int64                `json:"proposalID"`
+		Voter      string               `json:"voter"`
+		Options    []WeightedVoteOption `json:"options"` // exact weights, not their rounded decimal strings
+	}{
+		ProposalID: msg.ProposalID,
+		Voter:      sdk.MustBech32ifyVal(msg.Voter),
+		Options:    msg.Options,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.Voter}
+}
+
+//-----------------------------------------------------------
+// MsgCancelProposal
+type MsgCancelProposal struct {
+	ProposalID int64       `json:"proposalID"` // ID of the proposal
+	Proposer   sdk.Address `json:"proposer"`   // Address of the proposer
+}
+
+func NewMsgCancelProposal(proposer sdk.Address, proposalID int64) MsgCancelProposal {
+	return MsgCancelProposal{
+		ProposalID: proposalID,
+		Proposer:   proposer,
+	}
+}
+
+// Implements Msg.
+func (msg MsgCancelProposal) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgCancelProposal) ValidateBasic() sdk.Error {
+	if len(msg.Proposer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Proposer.String())
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	return nil
+}
+
+func (msg MsgCancelProposal) String() string {
+	return fmt.Sprintf("MsgCancelProposal{%v - %v}", msg.Proposer, msg.ProposalID)
+}
+
+// Implements Msg.
+func (msg MsgCancelProposal) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgCancelProposal) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ProposalID int64  `json:"proposalID"`
+		Proposer   string `json:"proposer"`
+	}{
+		ProposalID: msg.ProposalID,
+		Proposer:   sdk.MustBech32ifyVal(msg.Proposer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgCancelProposal) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.Proposer}
+}
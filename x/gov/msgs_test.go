@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/mock"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +31,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.Address{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +50,90 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for the changes of a parameter change proposal
+func TestMsgSubmitParamChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	change := ParamChange{"stake", "MaxValidators", "50"}
+	tests := []struct {
+		proposalType byte
+		changes      []ParamChange
+		expectPass   bool
+	}{
+		{ProposalTypeParameterChange, []ParamChange{change}, true},
+		{ProposalTypeParameterChange, []ParamChange{change, {"stake", "UnbondingTime", `"60"`}}, true},
+		{ProposalTypeParameterChange, nil, false},
+		{ProposalTypeParameterChange, []ParamChange{change, change}, false},
+		{ProposalTypeParameterChange, []ParamChange{{"", "MaxValidators", "50"}}, false},
+		{ProposalTypeParameterChange, []ParamChange{{"stake", "", "50"}}, false},
+		{ProposalTypeParameterChange, []ParamChange{{"stake", "MaxValidators", ""}}, false},
+		{ProposalTypeText, []ParamChange{change}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", tc.proposalType, addrs[0], coinsPos)
+		msg.Content = ParamChangeContent{tc.changes}
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+// test ValidateBasic for the plan of a software upgrade proposal
+func TestMsgSubmitUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalType byte
+		plan         upgrade.Plan
+		expectPass   bool
+	}{
+		{ProposalTypeSoftwareUpgrade, upgrade.Plan{Name: "v2", Height: 1000, Info: "release v2"}, true},
+		{ProposalTypeSoftwareUpgrade, upgrade.Plan{Name: "v2", Height: 1000, Info: ""}, true},
+		{ProposalTypeSoftwareUpgrade, upgrade.Plan{Name: "", Height: 1000, Info: "release v2"}, false},
+		{ProposalTypeSoftwareUpgrade, upgrade.Plan{Name: "v2", Height: 0, Info: "release v2"}, false},
+		{ProposalTypeText, upgrade.Plan{Name: "v2", Height: 1000, Info: "release v2"}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", tc.proposalType, addrs[0], coinsPos)
+		msg.Content = SoftwareUpgradeContent{tc.plan}
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+// test ValidateBasic for the spend of a community pool spend proposal
+func TestMsgSubmitPoolSpendProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalType byte
+		spend        CommunityPoolSpend
+		expectPass   bool
+	}{
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{addrs[0], coinsPos}, true},
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{addrs[0], coinsMulti}, true},
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{sdk.Address{}, coinsPos}, false},
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{addrs[0], coinsZero}, false},
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{addrs[0], coinsNeg}, false},
+		{ProposalTypeCommunityPoolSpend, CommunityPoolSpend{}, false},
+		{ProposalTypeText, CommunityPoolSpend{addrs[0], coinsPos}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", tc.proposalType, addrs[0], coinsPos)
+		msg.Content = tc.spend
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -103,3 +188,91 @@ func TestMsgVote(t *testing.T) {
 		}
 	}
 }
+
+// test ValidateBasic for MsgVoteWeighted
+func TestMsgVoteWeighted(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	half := sdk.NewRat(1, 2)
+	tests := []struct {
+		proposalID int64
+		voterAddr  sdk.Address
+		options    []WeightedVoteOption
+		expectPass bool
+	}{
+		{0, addrs[0], NewNonSplitVoteOption(OptionYes), true},
+		{0, addrs[0], []WeightedVoteOption{{OptionYes, half}, {OptionNo, half}}, true},
+		{0, addrs[0], []WeightedVoteOption{{OptionYes, sdk.NewRat(1, 3)}, {OptionAbstain, sdk.NewRat(1, 3)}, {OptionNoWithVeto, sdk.NewRat(1, 3)}}, true},
+		{-1, addrs[0], NewNonSplitVoteOption(OptionYes), false},
+		{0, sdk.Address{}, NewNonSplitVoteOption(OptionYes), false},
+		{0, addrs[0], nil, false},
+		{0, addrs[0], []WeightedVoteOption{{OptionYes, half}}, false},
+		{0, addrs[0], []WeightedVoteOption{{OptionYes, half}, {OptionYes, half}}, false},
+		{0, addrs[0], []WeightedVoteOption{{OptionYes, sdk.NewRat(3, 2)}, {OptionNo, sdk.NewRat(-1, 2)}}, false},
+		{0, addrs[0], []WeightedVoteOption{{VoteOption(0x13), half}, {OptionNo, half}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgVoteWeighted(tc.voterAddr, tc.proposalID, tc.options)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+// test GetSignBytes for MsgVoteWeighted signs the exact weights
+func TestMsgVoteWeightedSignBytes(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	third := NewMsgVoteWeighted(addrs[0], 0, []WeightedVoteOption{
+		{OptionYes, sdk.NewRat(1, 3)}, {OptionNo, sdk.NewRat(2, 3)}})
+	rounded := NewMsgVoteWeighted(addrs[0], 0, []WeightedVoteOption{
+		{OptionYes, sdk.NewRat(33333333333, 100000000000)}, {OptionNo, sdk.NewRat(66666666667, 100000000000)}})
+
+	// both weights print the same with ten decimals
+	require.Equal(t, WeightedVoteOptionsToString(third.Options), WeightedVoteOptionsToString(rounded.Options))
+	require.NotEqual(t, third.GetSignBytes(), rounded.GetSignBytes())
+	require.Equal(t, third.GetSignBytes(), NewMsgVoteWeighted(addrs[0], 0, third.Options).GetSignBytes())
+}
+
+// test ValidateBasic for MsgCancelProposal
+func TestMsgCancelProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalID   int64
+		proposerAddr sdk.Address
+		expectPass   bool
+	}{
+		{0, addrs[0], true},
+		{-1, addrs[0], false},
+		{0, sdk.Address{}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgCancelProposal(tc.proposerAddr, tc.proposalID)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestStringToWeightedVoteOptions(t *testing.T) {
+	options, err := StringToWeightedVoteOptions("Yes")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(options))
+	require.Equal(t, OptionYes, options[0].Option)
+	require.True(sdk.RatEq(t, sdk.OneRat(), options[0].Weight))
+
+	options, err = StringToWeightedVoteOptions("Yes=0.6,NoWithVeto=0.4")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(options))
+	require.Equal(t, OptionNoWithVeto, options[1].Option)
+	require.True(sdk.RatEq(t, sdk.NewRat(2, 5), options[1].Weight))
+
+	_, err = StringToWeightedVoteOptions("Yes=0.6,Maybe=0.4")
+	require.NotNil(t, err)
+	_, err = StringToWeightedVoteOptions("Yes=six")
+	require.NotNil(t, err)
+}
@@ -1,23 +1,108 @@
 package gov
 
 import (
+	"errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
+// default paramspace for the gov procedures
+const DefaultParamspace = "gov"
+
+// keys of the procedures in the paramspace
+var (
+	KeyDepositProcedure  = []byte("depositprocedure")
+	KeyVotingProcedure   = []byte("votingprocedure")
+	KeyTallyingProcedure = []byte("tallyingprocedure")
+)
+
+// ParamKeyTable declares the gov procedures
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable(
+		params.ParamSetPair{KeyDepositProcedure, &DepositProcedure{}, validateDepositProcedure},
+		params.ParamSetPair{KeyVotingProcedure, &VotingProcedure{}, validateVotingProcedure},
+		params.ParamSetPair{KeyTallyingProcedure, &TallyingProcedure{}, validateTallyingProcedure},
+	)
+}
+
 // Procedure around Deposits for governance
 type DepositProcedure struct {
 	MinDeposit       sdk.Coins `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period.
-	MaxDepositPeriod int64     `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	MaxDepositPeriod int64     `json:"max_deposit_period"` //  Maximum period in seconds of block time for Atom holders to deposit on a proposal. Initial value: 2 days
+	BurnVetoed       bool      `json:"burn_vetoed"`        //  Burn the deposits of vetoed proposals instead of refunding them. Initial value: true
+	BurnUnmetDeposit bool      `json:"burn_unmet_deposit"` //  Burn the deposits of proposals which never reach MinDeposit instead of refunding them. Initial value: true
+	CancelBurnRate   sdk.Rat   `json:"cancel_burn_rate"`   //  Proportion of the deposits burned when the proposer cancels a proposal. Initial value: 1/2
 }
 
 // Procedure around Tallying votes in governance
 type TallyingProcedure struct {
+	Quorum            sdk.Rat `json:"quorum"`             //  Minimum proportion of the bonded voting power that must vote for a proposal to be valid. Initial value: 1/3
 	Threshold         sdk.Rat `json:"threshold"`          //  Minimum propotion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto              sdk.Rat `json:"veto"`               //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
-	GovernancePenalty sdk.Rat `json:"governance_penalty"` //  Penalty if validator does not vote
+	GovernancePenalty sdk.Rat `json:"governance_penalty"` //  Proportion of the stake slashed from a bonded validator not voting on a proposal reaching the quorum. Initial value: 0
 }
 
 // Procedure around Voting in governance
 type VotingProcedure struct {
-	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period.
+	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period in seconds of block time. Initial value: 2 days
+}
+
+// default procedures
+func DefaultDepositProcedure() DepositProcedure {
+	return DepositProcedure{
+		MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
+		MaxDepositPeriod: 172800,
+		BurnVetoed:       true,
+		BurnUnmetDeposit: true,
+		CancelBurnRate:   sdk.NewRat(1, 2),
+	}
+}
+
+// nolint
+func DefaultVotingProcedure() VotingProcedure {
+	return VotingProcedure{
+		VotingPeriod: 172800,
+	}
+}
+
+// nolint
+func DefaultTallyingProcedure() TallyingProcedure {
+	return TallyingProcedure{
+		Quorum:            sdk.NewRat(1, 3),
+		Threshold:         sdk.NewRat(1, 2),
+		Veto:              sdk.NewRat(1, 3),
+		GovernancePenalty: sdk.ZeroRat(),
+	}
+}
+
+func validateDepositProcedure(value interface{}) error {
+	procedure := value.(DepositProcedure)
+	if !procedure.MinDeposit.IsValid() {
+		return errors.New("invalid minimum deposit")
+	}
+	if procedure.MaxDepositPeriod <= 0 {
+		return errors.New("deposit period must be positive")
+	}
+	if procedure.CancelBurnRate.LT(sdk.ZeroRat()) || procedure.CancelBurnRate.GT(sdk.OneRat()) {
+		return errors.New("cancel burn rate must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateVotingProcedure(value interface{}) error {
+	if value.(VotingProcedure).VotingPeriod <= 0 {
+		return errors.New("voting period must be positive")
+	}
+	return nil
+}
+
+func validateTallyingProcedure(value interface{}) error {
+	procedure := value.(TallyingProcedure)
+	for _, rat := range []sdk.Rat{procedure.Quorum, procedure.Threshold, procedure.Veto, procedure.GovernancePenalty} {
+		if rat.LT(sdk.ZeroRat()) || rat.GT(sdk.OneRat()) {
+			return errors.New("quorum, threshold, veto and penalty must be between 0 and 1")
+		}
+	}
+	return nil
 }
@@ -1,7 +1,11 @@
 package gov
 
 import (
+	"bytes"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // Type that represents Status as a byte
@@ -17,9 +21,10 @@ const (
 	StatusPassed        VoteStatus = 0x03
 	StatusRejected      VoteStatus = 0x04
 
-	ProposalTypeText            ProposalKind = 0x01
-	ProposalTypeParameterChange ProposalKind = 0x02
-	ProposalTypeSoftwareUpgrade ProposalKind = 0x03
+	ProposalTypeText               ProposalKind = 0x01
+	ProposalTypeParameterChange    ProposalKind = 0x02
+	ProposalTypeSoftwareUpgrade    ProposalKind = 0x03
+	ProposalTypeCommunityPoolSpend ProposalKind = 0x04
 )
 
 //-----------------------------------------------------------
@@ -40,14 +45,30 @@ type Proposal interface {
 	GetStatus() VoteStatus
 	SetStatus(VoteStatus)
 
-	GetSubmitBlock() int64
-	SetSubmitBlock(int64)
+	GetProposer() sdk.Address
+	SetProposer(sdk.Address)
+
+	GetSubmitTime() int64
+	SetSubmitTime(int64)
 
 	GetTotalDeposit() sdk.Coins
 	SetTotalDeposit(sdk.Coins)
 
-	GetVotingStartBlock() int64
-	SetVotingStartBlock(int64)
+	GetVotingStartTime() int64
+	SetVotingStartTime(int64)
+
+	GetTallyResult() TallyResult
+	SetTallyResult(TallyResult)
+}
+
+//-----------------------------------------------------------
+// ProposalContent interface
+
+// ProposalContent is the part of a proposal specific to its type, submitted
+// with MsgSubmitProposal. Text proposals carry no content.
+type ProposalContent interface {
+	ProposalType() ProposalKind
+	ValidateBasic() sdk.Error
 }
 
 // checks if two proposals are equal
@@ -57,9 +78,11 @@ func ProposalEqual(proposalA Proposal, proposalB Proposal) bool {
 		proposalA.GetDescription() != proposalB.GetDescription() ||
 		proposalA.GetProposalType() != proposalB.GetProposalType() ||
 		proposalA.GetStatus() != proposalB.GetStatus() ||
-		proposalA.GetSubmitBlock() != proposalB.GetSubmitBlock() ||
+		!bytes.Equal(proposalA.GetProposer(), proposalB.GetProposer()) ||
+		proposalA.GetSubmitTime() != proposalB.GetSubmitTime() ||
 		!(proposalA.GetTotalDeposit().IsEqual(proposalB.GetTotalDeposit())) ||
-		proposalA.GetVotingStartBlock() != proposalB.GetVotingStartBlock() {
+		proposalA.GetVotingStartTime() != proposalB.GetVotingStartTime() ||
+		!(proposalA.GetTallyResult().Equals(proposalB.GetTallyResult())) {
 		return false
 	}
 	return true
@@ -73,12 +96,15 @@ type TextProposal struct {
 	Description  string       `json:"description"`   //  Description of the proposal
 	ProposalType ProposalKind `json:"proposal_type"` //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 
-	Status VoteStatus `json:"string"` //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	Status   VoteStatus  `json:"string"`   //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	Proposer sdk.Address `json:"proposer"` //  Address of the proposer, who can cancel the proposal during its deposit period
 
-	SubmitBlock  int64     `json:"submit_block"`  //  Height of the block where TxGovSubmitProposal was included
+	SubmitTime   int64     `json:"submit_time"`   //  Time of the block where TxGovSubmitProposal was included
 	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
 
-	VotingStartBlock int64 `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingStartTime int64 `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+
+	TallyResult TallyResult `json:"tally_result"` //  Result of the tally, set once the voting period ends
 }
 
 // Implements Proposal Interface
@@ -95,27 +121,146 @@ func (tp TextProposal) GetProposalType() ProposalKind              { return tp.P
 func (tp *TextProposal) SetProposalType(proposalType ProposalKind) { tp.ProposalType = proposalType }
 func (tp TextProposal) GetStatus() VoteStatus                      { return tp.Status }
 func (tp *TextProposal) SetStatus(status VoteStatus)               { tp.Status = status }
-func (tp TextProposal) GetSubmitBlock() int64                      { return tp.SubmitBlock }
-func (tp *TextProposal) SetSubmitBlock(submitBlock int64)          { tp.SubmitBlock = submitBlock }
+func (tp TextProposal) GetProposer() sdk.Address                   { return tp.Proposer }
+func (tp *TextProposal) SetProposer(proposer sdk.Address)          { tp.Proposer = proposer }
+func (tp TextProposal) GetSubmitTime() int64                       { return tp.SubmitTime }
+func (tp *TextProposal) SetSubmitTime(submitTime int64)            { tp.SubmitTime = submitTime }
 func (tp TextProposal) GetTotalDeposit() sdk.Coins                 { return tp.TotalDeposit }
 func (tp *TextProposal) SetTotalDeposit(totalDeposit sdk.Coins)    { tp.TotalDeposit = totalDeposit }
-func (tp TextProposal) GetVotingStartBlock() int64                 { return tp.VotingStartBlock }
-func (tp *TextProposal) SetVotingStartBlock(votingStartBlock int64) {
-	tp.VotingStartBlock = votingStartBlock
+func (tp TextProposal) GetVotingStartTime() int64                  { return tp.VotingStartTime }
+func (tp *TextProposal) SetVotingStartTime(votingStartTime int64) {
+	tp.VotingStartTime = votingStartTime
+}
+func (tp TextProposal) GetTallyResult() TallyResult             { return tp.TallyResult }
+func (tp *TextProposal) SetTallyResult(tallyResult TallyResult) { tp.TallyResult = tallyResult }
+
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// ParamChange sets a parameter of a module to a JSON encoded value
+type ParamChange struct {
+	Subspace string `json:"subspace"` //  Module owning the parameter, eg. stake
+	Key      string `json:"key"`      //  Key of the parameter, eg. MaxValidators
+	Value    string `json:"value"`    //  JSON encoded value of the parameter
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s=%s", pc.Subspace, pc.Key, pc.Value)
+}
+
+// ParamChangeContent is the content of a parameter change proposal
+type ParamChangeContent struct {
+	Changes []ParamChange `json:"changes"` //  Changes applied together once the proposal passes
+}
+
+// Implements ProposalContent Interface
+var _ ProposalContent = ParamChangeContent{}
+
+// nolint
+func (c ParamChangeContent) ProposalType() ProposalKind { return ProposalTypeParameterChange }
+
+// at least one change and each parameter at most once
+func (c ParamChangeContent) ValidateBasic() sdk.Error {
+	if len(c.Changes) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "no parameter changes")
+	}
+	seen := make(map[string]bool)
+	for _, change := range c.Changes {
+		if len(change.Subspace) == 0 || len(change.Key) == 0 || len(change.Value) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("incomplete change %v", change))
+		}
+		param := change.Subspace + "/" + change.Key
+		if seen[param] {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("parameter %s changed more than once", param))
+		}
+		seen[param] = true
+	}
+	return nil
+}
+
+// ParameterChangeProposal applies its changes when it passes
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Changes applied together, or not at all if one of them fails
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+//-----------------------------------------------------------
+// Software Upgrade Proposals
+
+// SoftwareUpgradeContent is the content of a software upgrade proposal
+type SoftwareUpgradeContent struct {
+	Plan upgrade.Plan `json:"plan"` //  Plan scheduled once the proposal passes
+}
+
+// Implements ProposalContent Interface
+var _ ProposalContent = SoftwareUpgradeContent{}
+
+// nolint
+func (c SoftwareUpgradeContent) ProposalType() ProposalKind { return ProposalTypeSoftwareUpgrade }
+func (c SoftwareUpgradeContent) ValidateBasic() sdk.Error   { return c.Plan.ValidateBasic() }
+
+// SoftwareUpgradeProposal schedules its upgrade plan when it passes
+type SoftwareUpgradeProposal struct {
+	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Plan scheduled in the upgrade module
 }
 
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
+
+//-----------------------------------------------------------
+// Community Pool Spend Proposals
+
+// CommunityPoolSpend pays coins of the community pool to a recipient
+type CommunityPoolSpend struct {
+	Recipient sdk.Address `json:"recipient"` //  Address paid by the community pool
+	Amount    sdk.Coins   `json:"amount"`    //  Coins paid by the community pool
+}
+
+// Implements ProposalContent Interface, a spend is the content of a community
+// pool spend proposal
+var _ ProposalContent = CommunityPoolSpend{}
+
+// nolint
+func (spend CommunityPoolSpend) ProposalType() ProposalKind { return ProposalTypeCommunityPoolSpend }
+
+// a recipient and a positive amount
+func (spend CommunityPoolSpend) ValidateBasic() sdk.Error {
+	if len(spend.Recipient) == 0 {
+		return sdk.ErrInvalidAddress(spend.Recipient.String())
+	}
+	if !spend.Amount.IsValid() || !spend.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(spend.Amount.String())
+	}
+	return nil
+}
+
+// CommunityPoolSpendProposal pays its spend when it passes
+type CommunityPoolSpendProposal struct {
+	TextProposal
+	Spend CommunityPoolSpend `json:"spend"` //  Spend paid by the distribution module
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*CommunityPoolSpendProposal)(nil)
+
 // Current Active Proposals
 type ProposalQueue []int64
 
 // ProposalTypeToString for pretty prints of ProposalType
 func ProposalTypeToString(proposalType ProposalKind) string {
 	switch proposalType {
-	case 0x00:
+	case ProposalTypeText:
 		return "Text"
-	case 0x01:
+	case ProposalTypeParameterChange:
 		return "ParameterChange"
-	case 0x02:
+	case ProposalTypeSoftwareUpgrade:
 		return "SoftwareUpgrade"
+	case ProposalTypeCommunityPoolSpend:
+		return "CommunityPoolSpend"
 	default:
 		return ""
 	}
@@ -124,7 +269,8 @@ func ProposalTypeToString(proposalType ProposalKind) string {
 func validProposalType(proposalType ProposalKind) bool {
 	if proposalType == ProposalTypeText ||
 		proposalType == ProposalTypeParameterChange ||
-		proposalType == ProposalTypeSoftwareUpgrade {
+		proposalType == ProposalTypeSoftwareUpgrade ||
+		proposalType == ProposalTypeCommunityPoolSpend {
 		return true
 	}
 	return false
@@ -139,6 +285,8 @@ func StringToProposalType(str string) (ProposalKind, sdk.Error) {
 		return ProposalTypeParameterChange, nil
 	case "SoftwareUpgrade":
 		return ProposalTypeSoftwareUpgrade, nil
+	case "CommunityPoolSpend":
+		return ProposalTypeCommunityPoolSpend, nil
 	default:
 		return ProposalKind(0xff), ErrInvalidProposalType(DefaultCodespace, str)
 	}
@@ -179,26 +327,53 @@ func StringToStatus(status string) VoteStatus {
 //-----------------------------------------------------------
 // Rest Proposals
 type ProposalRest struct {
-	ProposalID       int64     `json:"proposal_id"`        //  ID of the proposal
-	Title            string    `json:"title"`              //  Title of the proposal
-	Description      string    `json:"description"`        //  Description of the proposal
-	ProposalType     string    `json:"proposal_type"`      //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Status           string    `json:"string"`             //  Status of the Proposal {Pending, Active, Passed, Rejected}
-	SubmitBlock      int64     `json:"submit_block"`       //  Height of the block where TxGovSubmitProposal was included
-	TotalDeposit     sdk.Coins `json:"total_deposit"`      //  Current deposit on this proposal. Initial value is set at InitialDeposit
-	VotingStartBlock int64     `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	ProposalID      int64       `json:"proposal_id"`       //  ID of the proposal
+	Title           string      `json:"title"`             //  Title of the proposal
+	Description     string      `json:"description"`       //  Description of the proposal
+	ProposalType    string      `json:"proposal_type"`     //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Status          string      `json:"string"`            //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	Proposer        string      `json:"proposer"`          //  Address of the proposer
+	SubmitTime      int64       `json:"submit_time"`       //  Time of the block where TxGovSubmitProposal was included
+	TotalDeposit    sdk.Coins   `json:"total_deposit"`     //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	VotingStartTime int64       `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	TallyResult     TallyResult `json:"tally_result"`      //  Result of the tally, set once the voting period ends
+
+	ParamChanges       []ParamChange       `json:"param_changes,omitempty"`        //  Changes of a parameter change proposal
+	UpgradePlan        *upgrade.Plan       `json:"upgrade_plan,omitempty"`         //  Plan of a software upgrade proposal
+	CommunityPoolSpend *CommunityPoolSpend `json:"community_pool_spend,omitempty"` //  Spend of a community pool spend proposal
 }
 
 // Turn any Proposal to a ProposalRest
 func ProposalToRest(proposal Proposal) ProposalRest {
+	var paramChanges []ParamChange
+	if pcp, ok := proposal.(*ParameterChangeProposal); ok {
+		paramChanges = pcp.Changes
+	}
+	var upgradePlan *upgrade.Plan
+	if sup, ok := proposal.(*SoftwareUpgradeProposal); ok {
+		upgradePlan = &sup.Plan
+	}
+	var spend *CommunityPoolSpend
+	if cpsp, ok := proposal.(*CommunityPoolSpendProposal); ok {
+		spend = &cpsp.Spend
+	}
+	var proposer string
+	if len(proposal.GetProposer()) != 0 {
+		proposer = sdk.MustBech32ifyAcc(proposal.GetProposer())
+	}
 	return ProposalRest{
-		ProposalID:       proposal.GetProposalID(),
-		Title:            proposal.GetTitle(),
-		Description:      proposal.GetDescription(),
-		ProposalType:     ProposalTypeToString(proposal.GetProposalType()),
-		Status:           StatusToString(proposal.GetStatus()),
-		SubmitBlock:      proposal.GetSubmitBlock(),
-		TotalDeposit:     proposal.GetTotalDeposit(),
-		VotingStartBlock: proposal.GetVotingStartBlock(),
+		ProposalID:         proposal.GetProposalID(),
+		Title:              proposal.GetTitle(),
+		Description:        proposal.GetDescription(),
+		ProposalType:       ProposalTypeToString(proposal.GetProposalType()),
+		Status:             StatusToString(proposal.GetStatus()),
+		Proposer:           proposer,
+		SubmitTime:         proposal.GetSubmitTime(),
+		TotalDeposit:       proposal.GetTotalDeposit(),
+		VotingStartTime:    proposal.GetVotingStartTime(),
+		TallyResult:        proposal.GetTallyResult(),
+		ParamChanges:       paramChanges,
+		UpgradePlan:        upgradePlan,
+		CommunityPoolSpend: spend,
 	}
 }
@@ -6,70 +6,150 @@ import (
 
 // validatorGovInfo used for tallying
 type validatorGovInfo struct {
-	Address         sdk.Address // sdk.Address of the validator owner
-	Power           sdk.Rat     // Power of a Validator
-	DelegatorShares sdk.Rat     // Total outstanding delegator shares
-	Minus           sdk.Rat     // Minus of validator, used to compute validator's voting power
-	Vote            VoteOption  // Vote of the validator
+	Address         sdk.Address          // sdk.Address of the validator owner
+	Power           sdk.Rat              // Power of a Validator
+	DelegatorShares sdk.Rat              // Total outstanding delegator shares
+	Minus           sdk.Rat              // Minus of validator, used to compute validator's voting power
+	Vote            []WeightedVoteOption // Vote of the validator, empty if it didn't vote
 }
 
-func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonVoting []sdk.Address) {
+// TallyResult is the voting power cast on each option of a proposal
+type TallyResult struct {
+	Yes        sdk.Rat `json:"yes"`
+	Abstain    sdk.Rat `json:"abstain"`
+	No         sdk.Rat `json:"no"`
+	NoWithVeto sdk.Rat `json:"no_with_veto"`
+}
+
+// tally result of a proposal nobody voted on
+func EmptyTallyResult() TallyResult {
+	return TallyResult{
+		Yes:        sdk.ZeroRat(),
+		Abstain:    sdk.ZeroRat(),
+		No:         sdk.ZeroRat(),
+		NoWithVeto: sdk.ZeroRat(),
+	}
+}
+
+// checks if two tally results are equal
+func (resultA TallyResult) Equals(resultB TallyResult) bool {
+	return resultA.Yes.Equal(resultB.Yes) &&
+		resultA.Abstain.Equal(resultB.Abstain) &&
+		resultA.No.Equal(resultB.No) &&
+		resultA.NoWithVeto.Equal(resultB.NoWithVeto)
+}
+
+// tally the votes of a proposal, the non voting validators are only returned
+// when the quorum is reached so that ignoring a proposal which fails for lack
+// of votes isn't penalized
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, vetoed bool, tallyResults TallyResult, nonVoting []sdk.Address) {
+	var validators []sdk.Validator
+	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		validators = append(validators, validator)
+		return false
+	})
+
+	// iterate over all the votes, collecting the delegations of the voters
+	var votes []Vote
+	delegations := make(map[string][]sdk.Delegation)
+	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		vote := Vote{}
+		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+		votes = append(votes, vote)
+
+		keeper.ds.IterateDelegations(ctx, vote.Voter, func(index int64, delegation sdk.Delegation) (stop bool) {
+			delegations[vote.Voter.String()] = append(delegations[vote.Voter.String()], delegation)
+			return false
+		})
+
+		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+	}
+	votesIterator.Close()
+
+	tallyResults, nonVoting = TallyVotes(validators, votes, delegations)
+
+	totalBondedPower := sdk.ZeroRat()
+	for _, validator := range validators {
+		totalBondedPower = totalBondedPower.Add(validator.GetPower())
+	}
+	totalVotingPower := tallyResults.Yes.Add(tallyResults.Abstain).Add(tallyResults.No).Add(tallyResults.NoWithVeto)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+
+	// If there is no bonded power or less than quorum of it voted, proposal fails
+	if totalBondedPower.IsZero() || totalVotingPower.Quo(totalBondedPower).LT(tallyingProcedure.Quorum) {
+		return false, false, tallyResults, nil
+	}
+	// If no one votes, proposal fails
+	if totalVotingPower.Sub(tallyResults.Abstain).Equal(sdk.ZeroRat()) {
+		return false, false, tallyResults, nonVoting
+	}
+	// If more than 1/3 of voters veto, proposal fails
+	if tallyResults.NoWithVeto.Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
+		return false, true, tallyResults, nonVoting
+	}
+	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
+	if tallyResults.Yes.Quo(totalVotingPower.Sub(tallyResults.Abstain)).GT(tallyingProcedure.Threshold) {
+		return true, false, tallyResults, nonVoting
+	}
+	// If more than 1/2 of non-abstaining voters vote No, proposal fails
+	return false, false, tallyResults, nonVoting
+}
+
+// TallyVotes computes the voting power cast on each option by the votes on a
+// proposal, given the bonded validators and the delegations of the voters.
+// Delegators inherit the vote of their validator unless they voted themselves.
+// It doesn't read the store so clients can tally proposals still being voted on.
+func TallyVotes(validators []sdk.Validator, votes []Vote, delegations map[string][]sdk.Delegation) (tallyResults TallyResult, nonVoting []sdk.Address) {
 	results := make(map[VoteOption]sdk.Rat)
 	results[OptionYes] = sdk.ZeroRat()
 	results[OptionAbstain] = sdk.ZeroRat()
 	results[OptionNo] = sdk.ZeroRat()
 	results[OptionNoWithVeto] = sdk.ZeroRat()
 
-	totalVotingPower := sdk.ZeroRat()
 	currValidators := make(map[string]validatorGovInfo)
-
-	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+	for _, validator := range validators {
 		currValidators[validator.GetOwner().String()] = validatorGovInfo{
 			Address:         validator.GetOwner(),
 			Power:           validator.GetPower(),
 			DelegatorShares: validator.GetDelegatorShares(),
 			Minus:           sdk.ZeroRat(),
-			Vote:            OptionEmpty,
 		}
-		return false
-	})
-
-	// iterate over all the votes
-	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
-	for ; votesIterator.Valid(); votesIterator.Next() {
-		vote := &Vote{}
-		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), vote)
+	}
 
+	for _, vote := range votes {
 		// if validator, just record it in the map
 		// if delegator tally voting power
 		if val, ok := currValidators[vote.Voter.String()]; ok {
-			val.Vote = vote.Option
+			val.Vote = vote.Options
 			currValidators[vote.Voter.String()] = val
-		} else {
-
-			keeper.ds.IterateDelegations(ctx, vote.Voter, func(index int64, delegation sdk.Delegation) (stop bool) {
-				val := currValidators[delegation.GetValidator().String()]
-				val.Minus = val.Minus.Add(delegation.GetBondShares())
-				currValidators[delegation.GetValidator().String()] = val
-
-				delegatorShare := delegation.GetBondShares().Quo(val.DelegatorShares)
-				votingPower := val.Power.Mul(delegatorShare)
-
-				results[vote.Option] = results[vote.Option].Add(votingPower)
-				totalVotingPower = totalVotingPower.Add(votingPower)
-
-				return false
-			})
+			continue
 		}
 
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		for _, delegation := range delegations[vote.Voter.String()] {
+			// delegations to validators which are not bonded carry no voting power
+			val, ok := currValidators[delegation.GetValidator().String()]
+			if !ok {
+				continue
+			}
+			val.Minus = val.Minus.Add(delegation.GetBondShares())
+			currValidators[delegation.GetValidator().String()] = val
+
+			delegatorShare := delegation.GetBondShares().Quo(val.DelegatorShares)
+			votingPower := val.Power.Mul(delegatorShare)
+
+			for _, option := range vote.Options {
+				results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+			}
+		}
 	}
-	votesIterator.Close()
 
 	// Iterate over the validators again to tally their voting power and see who didn't vote
 	nonVoting = []sdk.Address{}
-	for _, val := range currValidators {
-		if val.Vote == OptionEmpty {
+	for _, validator := range validators {
+		val := currValidators[validator.GetOwner().String()]
+		if len(val.Vote) == 0 {
 			nonVoting = append(nonVoting, val.Address)
 			continue
 		}
@@ -77,24 +157,16 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
 		votingPower := val.Power.Mul(percentAfterMinus)
 
-		results[val.Vote] = results[val.Vote].Add(votingPower)
-		totalVotingPower = totalVotingPower.Add(votingPower)
+		for _, option := range val.Vote {
+			results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+		}
 	}
 
-	tallyingProcedure := keeper.GetTallyingProcedure()
-
-	// If no one votes, proposal fails
-	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroRat()) {
-		return false, nonVoting
-	}
-	// If more than 1/3 of voters veto, proposal fails
-	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
-		return false, nonVoting
+	tallyResults = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
 	}
-	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
-	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingProcedure.Threshold) {
-		return true, nonVoting
-	}
-	// If more than 1/2 of non-abstaining voters vote No, proposal fails
-	return false, nonVoting
+	return tallyResults, nonVoting
 }
@@ -29,7 +29,7 @@ func TestTallyNoOneVotes(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
 }
@@ -53,12 +53,12 @@ func TestTallyOnlyValidatorsAllYes(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.True(t, passes)
 }
@@ -80,14 +80,15 @@ func TestTallyOnlyValidators51No(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, vetoed, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
+	require.False(t, vetoed)
 }
 
 func TestTallyOnlyValidators51Yes(t *testing.T) {
@@ -109,14 +110,14 @@ func TestTallyOnlyValidators51Yes(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.True(t, passes)
 }
@@ -140,16 +141,17 @@ func TestTallyOnlyValidatorsVetoed(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionNoWithVeto)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionNoWithVeto))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, vetoed, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
+	require.True(t, vetoed)
 }
 
 func TestTallyOnlyValidatorsAbstainPasses(t *testing.T) {
@@ -171,14 +173,14 @@ func TestTallyOnlyValidatorsAbstainPasses(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionAbstain)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionAbstain))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.True(t, passes)
 }
@@ -202,14 +204,14 @@ func TestTallyOnlyValidatorsAbstainFails(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionAbstain)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionAbstain))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
 }
@@ -233,18 +235,65 @@ func TestTallyOnlyValidatorsNonVoter(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, nonVoting := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, nonVoting := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
 	require.Equal(t, 1, len(nonVoting))
 	require.Equal(t, addrs[0], nonVoting[0])
 }
 
+func TestTallyOnlyValidatorsQuorumNotReached(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	stakeHandler(ctx, val1CreateMsg)
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 6), dummyDescription)
+	stakeHandler(ctx, val2CreateMsg)
+	val3CreateMsg := stake.NewMsgCreateValidator(addrs[2], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 7), dummyDescription)
+	stakeHandler(ctx, val3CreateMsg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	// 5 of 18 is below the quorum of 1/3
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+
+	passes, _, tallyResults, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.False(t, passes)
+	require.True(sdk.RatEq(t, sdk.NewRat(5), tallyResults.Yes))
+	require.True(sdk.RatEq(t, sdk.ZeroRat(), tallyResults.No))
+
+	// 11 of 18 reaches it
+	proposal = keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID = proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err = keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionAbstain))
+	require.Nil(t, err)
+
+	passes, _, tallyResults, _ = tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.True(t, passes)
+	require.True(sdk.RatEq(t, sdk.NewRat(5), tallyResults.Yes))
+	require.True(sdk.RatEq(t, sdk.NewRat(6), tallyResults.Abstain))
+}
+
 func TestTallyDelgatorOverride(t *testing.T) {
 	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
@@ -267,16 +316,16 @@ func TestTallyDelgatorOverride(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[3], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[3], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
 }
@@ -303,14 +352,14 @@ func TestTallyDelgatorInherit(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionNo)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
 
-	passes, nonVoting := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, nonVoting := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.True(t, passes)
 	require.Equal(t, 0, len(nonVoting))
@@ -340,16 +389,16 @@ func TestTallyDelgatorMultipleOverride(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionYes)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[3], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[3], NewNonSplitVoteOption(OptionNo))
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
 }
@@ -378,14 +427,155 @@ func TestTallyDelgatorMultipleInherit(t *testing.T) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 
-	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionNo))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionNo))
+	require.Nil(t, err)
+
+	passes, _, _, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.False(t, passes)
+}
+
+func TestTallyVotesDelegatorOverride(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	stakeHandler(ctx, val1CreateMsg)
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 6), dummyDescription)
+	stakeHandler(ctx, val2CreateMsg)
+	val3CreateMsg := stake.NewMsgCreateValidator(addrs[2], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 7), dummyDescription)
+	stakeHandler(ctx, val3CreateMsg)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], addrs[2], sdk.NewCoin("steak", 30))
+	stakeHandler(ctx, delegator1Msg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[3], NewNonSplitVoteOption(OptionNo))
+	require.Nil(t, err)
+
+	// tally from the live state, as a client would
+	var validators []sdk.Validator
+	sk.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		validators = append(validators, validator)
+		return false
+	})
+	var votes []Vote
+	delegations := make(map[string][]sdk.Delegation)
+	for _, voter := range []sdk.Address{addrs[0], addrs[2], addrs[3]} {
+		vote, found := keeper.GetVote(ctx, proposalID, voter)
+		require.True(t, found)
+		votes = append(votes, vote)
+		sk.IterateDelegations(ctx, voter, func(index int64, delegation sdk.Delegation) (stop bool) {
+			delegations[voter.String()] = append(delegations[voter.String()], delegation)
+			return false
+		})
+	}
+
+	tallyResults, nonVoting := TallyVotes(validators, votes, delegations)
+
+	require.True(sdk.RatEq(t, sdk.NewRat(12), tallyResults.Yes))
+	require.True(sdk.RatEq(t, sdk.NewRat(30), tallyResults.No))
+	require.Equal(t, []sdk.Address{addrs[1]}, nonVoting)
+
+	// the votes are left untouched and the final tally agrees
+	_, found := keeper.GetVote(ctx, proposalID, addrs[3])
+	require.True(t, found)
+
+	passes, _, finalResults, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.False(t, passes)
+	require.True(t, tallyResults.Equals(finalResults))
+}
+
+func TestTallyDelgatorOverrideSplitVote(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	stakeHandler(ctx, val1CreateMsg)
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 6), dummyDescription)
+	stakeHandler(ctx, val2CreateMsg)
+	val3CreateMsg := stake.NewMsgCreateValidator(addrs[2], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 7), dummyDescription)
+	stakeHandler(ctx, val3CreateMsg)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], addrs[2], sdk.NewCoin("steak", 30))
+	stakeHandler(ctx, delegator1Msg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionNo))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], []WeightedVoteOption{{OptionYes, sdk.NewRat(1, 2)}, {OptionNo, sdk.NewRat(1, 2)}})
+	require.Nil(t, err)
+	// the delegator overrides the split vote of its validator with its own
+	err = keeper.AddVote(ctx, proposalID, addrs[3], []WeightedVoteOption{{OptionYes, sdk.NewRat(2, 3)}, {OptionAbstain, sdk.NewRat(1, 3)}})
+	require.Nil(t, err)
+
+	passes, _, tallyResults, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.True(t, passes)
+	require.True(sdk.RatEq(t, sdk.NewRat(59, 2), tallyResults.Yes))
+	require.True(sdk.RatEq(t, sdk.NewRat(10), tallyResults.Abstain))
+	require.True(sdk.RatEq(t, sdk.NewRat(17, 2), tallyResults.No))
+	require.True(sdk.RatEq(t, sdk.ZeroRat(), tallyResults.NoWithVeto))
+}
+
+func TestTallyDelgatorInheritSplitVote(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription)
+	stakeHandler(ctx, val1CreateMsg)
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 6), dummyDescription)
+	stakeHandler(ctx, val2CreateMsg)
+	val3CreateMsg := stake.NewMsgCreateValidator(addrs[2], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 7), dummyDescription)
+	stakeHandler(ctx, val3CreateMsg)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], addrs[2], sdk.NewCoin("steak", 30))
+	stakeHandler(ctx, delegator1Msg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNo)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], NewNonSplitVoteOption(OptionYes))
 	require.Nil(t, err)
-	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionNo)
+	// the delegator inherits the split vote of its validator
+	err = keeper.AddVote(ctx, proposalID, addrs[2], []WeightedVoteOption{{OptionYes, sdk.NewRat(1, 4)}, {OptionNoWithVeto, sdk.NewRat(3, 4)}})
 	require.Nil(t, err)
 
-	passes, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	passes, _, tallyResults, _ := tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
 
 	require.False(t, passes)
+	require.True(sdk.RatEq(t, sdk.NewRat(81, 4), tallyResults.Yes))
+	require.True(sdk.RatEq(t, sdk.NewRat(111, 4), tallyResults.NoWithVeto))
 }
@@ -12,13 +12,20 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/mock"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
 // initialize the mock application for this module
 func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper, []sdk.Address, []crypto.PubKey, []crypto.PrivKey) {
+	return getMockAppWithSendHooks(t, numGenAccs, nil)
+}
+
+// initialize the mock application for this module, with the given bank send hooks
+func getMockAppWithSendHooks(t *testing.T, numGenAccs int64, hooks bank.SendHooks) (*mock.App, Keeper, stake.Keeper, []sdk.Address, []crypto.PubKey, []crypto.PrivKey) {
 	mapp := mock.NewApp()
 
 	stake.RegisterWire(mapp.Cdc)
@@ -26,13 +33,24 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 
 	keyStake := sdk.NewKVStoreKey("stake")
 	keyGov := sdk.NewKVStoreKey("gov")
-
-	ck := bank.NewKeeper(mapp.AccountMapper)
-	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
-	keeper := NewKeeper(mapp.Cdc, keyGov, ck, sk, DefaultCodespace)
+	keyParams := sdk.NewKVStoreKey("params")
+	keyBank := sdk.NewKVStoreKey("bank")
+
+	ck := bank.NewKeeper(mapp.Cdc, keyBank, mapp.AccountMapper).WithModuleAccounts(map[string][]string{
+		stake.ModuleName: {auth.Minter, auth.Burner, auth.Staking},
+		ModuleName:       {auth.Burner},
+	}).WithHooks(hooks)
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, paramsKeeper.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace))
+	keeper := NewKeeper(mapp.Cdc, keyGov, ck, paramsKeeper.Subspace(DefaultParamspace), sk, DefaultCodespace)
+	govSpace, _ := paramsKeeper.GetSubspace(DefaultParamspace)
+	keeper = keeper.WithParamChangeHandlers(map[string]ParamChangeHandler{
+		stake.DefaultParamspace: sk,
+		DefaultParamspace:       govSpace,
+	})
 	mapp.Router().AddRoute("gov", NewHandler(keeper))
 
-	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov}))
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyBank, keyStake, keyGov, keyParams}))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
 	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk))
@@ -46,7 +64,7 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 // gov and stake endblocker
 func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-		tags, _ := EndBlocker(ctx, keeper)
+		tags := EndBlocker(ctx, keeper)
 		return abci.ResponseEndBlock{
 			Tags: tags,
 		}
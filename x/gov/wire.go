@@ -10,9 +10,23 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "cosmos-sdk/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "cosmos-sdk/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteWeighted{}, "cosmos-sdk/MsgVoteWeighted", nil)
+	cdc.RegisterConcrete(MsgCancelProposal{}, "cosmos-sdk/MsgCancelProposal", nil)
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&TextProposal{}, "gov/TextProposal", nil)
+	cdc.RegisterConcrete(&ParameterChangeProposal{}, "gov/ParameterChangeProposal", nil)
+	cdc.RegisterConcrete(&SoftwareUpgradeProposal{}, "gov/SoftwareUpgradeProposal", nil)
+	cdc.RegisterConcrete(&CommunityPoolSpendProposal{}, "gov/CommunityPoolSpendProposal", nil)
+
+	cdc.RegisterInterface((*ProposalContent)(nil), nil)
+	cdc.RegisterConcrete(ParamChangeContent{}, "gov/ParamChangeContent", nil)
+	cdc.RegisterConcrete(SoftwareUpgradeContent{}, "gov/SoftwareUpgradeContent", nil)
+	cdc.RegisterConcrete(CommunityPoolSpend{}, "gov/CommunityPoolSpend", nil)
 }
 
 var msgCdc = wire.NewCodec()
+
+func init() {
+	RegisterWire(msgCdc)
+}
@@ -27,6 +27,7 @@ type transferBody struct {
 	SrcChainID       string    `json:"src_chain_id"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
+	TimeoutHeight    int64     `json:"timeout_height"`
 	Gas              int64     `json:"gas"`
 }
 
@@ -85,6 +86,7 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
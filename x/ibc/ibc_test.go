@@ -34,7 +34,7 @@ func newAddress() crypto.Address {
 
 func getCoins(ck bank.Keeper, ctx sdk.Context, addr crypto.Address) (sdk.Coins, sdk.Error) {
 	zero := sdk.Coins(nil)
-	coins, _, err := ck.AddCoins(ctx, addr, zero)
+	coins, _, err := ck.MintCoins(ctx, addr, zero)
 	return coins, err
 }
 
@@ -63,7 +63,7 @@ func TestIBC(t *testing.T) {
 	ctx := defaultContext(key)
 
 	am := auth.NewAccountMapper(cdc, key, &auth.BaseAccount{})
-	ck := bank.NewKeeper(am)
+	ck := bank.NewKeeper(cdc, key, am)
 
 	src := newAddress()
 	dest := newAddress()
@@ -71,7 +71,7 @@ func TestIBC(t *testing.T) {
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, _, err := ck.MintCoins(ctx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
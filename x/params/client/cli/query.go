@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tmlibs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params/client"
+)
+
+// GetCmdQueryParams returns a query command displaying the current parameters
+// of a module, queried with the querier of the module if it has one
+func GetCmdQueryParams(storeName string, cdc *wire.Codec, queriers map[string]client.ModuleParamsQuerier) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params [subspace]",
+		Short: "Query the current parameters of a module",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subspace := args[0]
+
+			ctx := context.NewCoreContextFromViper()
+			all, err := client.QueryModuleParams(ctx, cdc, storeName, subspace, queriers)
+			if err != nil {
+				return err
+			}
+			if len(all) == 0 {
+				return fmt.Errorf("no parameters set in subspace %s", subspace)
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+
+			case "text":
+				for _, param := range all {
+					fmt.Printf("%s/%s = %s\n", param.Subspace, param.Key, param.Value)
+				}
+
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, all)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryParamHistory returns a query command displaying the changes of a
+// parameter
+func GetCmdQueryParamHistory(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "param-history [subspace] [key]",
+		Short: "Query the changes of a parameter with the heights and proposals which made them",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			records, err := client.QueryParamHistory(ctx, cdc, storeName, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+
+			case "text":
+				if len(records) == 0 {
+					fmt.Printf("parameter %s/%s never changed\n", args[0], args[1])
+				}
+				for _, record := range records {
+					fmt.Println(record.String())
+				}
+
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, records)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
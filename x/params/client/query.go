@@ -0,0 +1,66 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Param is the current JSON encoded value of a parameter
+type Param struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// QueryParams queries the current parameters of a subspace
+func QueryParams(ctx context.CoreContext, cdc *wire.Codec, storeName string, subspace string) ([]Param, error) {
+	prefix := []byte(subspace + "/")
+	kvs, err := ctx.QuerySubspace(cdc, prefix, storeName)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []Param{}
+	for _, kv := range kvs {
+		all = append(all, Param{
+			Subspace: subspace,
+			Key:      string(kv.Key[len(prefix):]),
+			Value:    string(kv.Value),
+		})
+	}
+	return all, nil
+}
+
+// ModuleParamsQuerier queries the current parameters of a module keeping them
+// outside of the params store
+type ModuleParamsQuerier func(ctx context.CoreContext, cdc *wire.Codec) ([]Param, error)
+
+// QueryModuleParams queries the current parameters of a module, with the
+// querier of the module if it keeps them outside of the params store
+func QueryModuleParams(ctx context.CoreContext, cdc *wire.Codec, storeName string, subspace string, queriers map[string]ModuleParamsQuerier) ([]Param, error) {
+	querier, ok := queriers[subspace]
+	if ok {
+		return querier(ctx, cdc)
+	}
+	return QueryParams(ctx, cdc, storeName, subspace)
+}
+
+// QueryParamHistory queries the changes of a parameter ordered by height
+func QueryParamHistory(ctx context.CoreContext, cdc *wire.Codec, storeName string, subspace string, key string) ([]params.ParamChangeRecord, error) {
+	kvs, err := ctx.QuerySubspace(cdc, params.HistoryKey(subspace, key), storeName)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []params.ParamChangeRecord{}
+	for _, kv := range kvs {
+		var record params.ParamChangeRecord
+		err = cdc.UnmarshalBinary(kv.Value, &record)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
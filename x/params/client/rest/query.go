@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params/client"
+)
+
+// RegisterRoutes registers params-related REST handlers to a router, the
+// parameters of the modules with a querier are queried with it
+func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, storeName string, queriers map[string]client.ModuleParamsQuerier) {
+	r.HandleFunc(
+		"/params/{subspace}",
+		paramsHandlerFn(ctx, storeName, cdc, queriers),
+	).Methods("GET")
+	r.HandleFunc(
+		"/params/{subspace}/history",
+		paramHistoryHandlerFn(ctx, storeName, cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the current parameters of a module
+func paramsHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec, queriers map[string]client.ModuleParamsQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		all, err := client.QueryModuleParams(ctx, cdc, storeName, mux.Vars(r)["subspace"], queriers)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query the parameters. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(all)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the changes of a parameter of a module, the
+// key is a query parameter as the keys of some modules contain slashes
+func paramHistoryHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subspace := mux.Vars(r)["subspace"]
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("key required but not specified"))
+			return
+		}
+
+		records, err := client.QueryParamHistory(ctx, cdc, storeName, subspace, key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query the parameter history. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(records)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
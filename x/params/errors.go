@@ -0,0 +1,26 @@
+//nolint
+package params
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default params codespace
+	DefaultCodespace sdk.CodespaceType = 13
+
+	CodeUnknownParam      CodeType = 101
+	CodeInvalidParamValue CodeType = 102
+)
+
+func ErrUnknownParam(codespace sdk.CodespaceType, subspace string, key string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownParam, fmt.Sprintf("parameter %s/%s is not declared", subspace, key))
+}
+func ErrInvalidParamValue(codespace sdk.CodespaceType, subspace string, key string, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamValue, fmt.Sprintf("invalid value of parameter %s/%s: %s", subspace, key, msg))
+}
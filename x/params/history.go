@@ -0,0 +1,152 @@
+package params
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// NoProposalID is the proposal ID of the changes which weren't made by a
+// governance proposal
+const NoProposalID int64 = -1
+
+// ParamChangeRecord is an entry of the history of a parameter, the value is
+// JSON encoded
+type ParamChangeRecord struct {
+	Height     int64  `json:"height"`
+	ProposalID int64  `json:"proposal_id"`
+	Subspace   string `json:"subspace"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+}
+
+// nolint
+func (r ParamChangeRecord) String() string {
+	proposal := "-"
+	if r.ProposalID != NoProposalID {
+		proposal = fmt.Sprintf("%d", r.ProposalID)
+	}
+	return fmt.Sprintf("height %d, proposal %s: %s/%s = %s", r.Height, proposal, r.Subspace, r.Key, r.Value)
+}
+
+// HistoryPrefix prefixes the history of the parameters in the params store,
+// it can't collide with the values stored under the names of the subspaces
+var HistoryPrefix = []byte{0x00}
+
+// HistoryKey is the prefix of the records of the history of a parameter,
+// which are keyed by height
+func HistoryKey(subspace string, key string) []byte {
+	res := make([]byte, 0, len(HistoryPrefix)+2+len(subspace)+len(key))
+	res = append(res, HistoryPrefix...)
+	res = append(res, byte(len(subspace)))
+	res = append(res, subspace...)
+	res = append(res, byte(len(key)))
+	return append(res, key...)
+}
+
+// key of a record of the history of a parameter, the index orders the
+// changes made at the same height
+func historyRecordKey(subspace string, key string, height int64, index int64) []byte {
+	prefix := HistoryKey(subspace, key)
+	res := make([]byte, len(prefix)+16)
+	copy(res, prefix)
+	binary.BigEndian.PutUint64(res[len(prefix):], uint64(height))
+	binary.BigEndian.PutUint64(res[len(prefix)+8:], uint64(index))
+	return res
+}
+
+type contextKey int
+
+const contextKeyProposalID contextKey = iota
+
+// WithProposalID returns a context recording the parameter changes made with
+// it as caused by the proposal
+func WithProposalID(ctx sdk.Context, proposalID int64) sdk.Context {
+	return ctx.WithValue(contextKeyProposalID, proposalID)
+}
+
+func proposalIDFromContext(ctx sdk.Context) int64 {
+	proposalID, ok := ctx.Value(contextKeyProposalID).(int64)
+	if !ok {
+		return NoProposalID
+	}
+	return proposalID
+}
+
+// append a change of a parameter to its history
+func recordChange(ctx sdk.Context, cdc *wire.Codec, storeKey sdk.StoreKey, subspace string, key string, value string) {
+	store := ctx.KVStore(storeKey)
+	height := ctx.BlockHeight()
+
+	var index int64
+	heightPrefix := historyRecordKey(subspace, key, height, 0)
+	iterator := sdk.KVStorePrefixIterator(store, heightPrefix[:len(heightPrefix)-8])
+	for ; iterator.Valid(); iterator.Next() {
+		index++
+	}
+	iterator.Close()
+
+	record := ParamChangeRecord{
+		Height:     height,
+		ProposalID: proposalIDFromContext(ctx),
+		Subspace:   subspace,
+		Key:        key,
+		Value:      value,
+	}
+	store.Set(historyRecordKey(subspace, key, height, index), cdc.MustMarshalBinary(record))
+}
+
+// RecordParamChange appends a change of a parameter stored outside of the
+// params store to its history
+func (k Keeper) RecordParamChange(ctx sdk.Context, subspace string, key string, value string) {
+	recordChange(ctx, k.cdc, k.key, subspace, key, value)
+}
+
+// GetParamHistory returns the changes of a parameter ordered by height
+func (k Keeper) GetParamHistory(ctx sdk.Context, subspace string, key string) []ParamChangeRecord {
+	store := ctx.KVStore(k.key)
+	iterator := sdk.KVStorePrefixIterator(store, HistoryKey(subspace, key))
+	defer iterator.Close()
+
+	records := []ParamChangeRecord{}
+	for ; iterator.Valid(); iterator.Next() {
+		var record ParamChangeRecord
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+
+// ChangeHandler checks and applies the changes of the parameters of a module
+// proposed to governance
+type ChangeHandler interface {
+	CheckParamChange(key string, value string) sdk.Error
+	ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error
+}
+
+// WithHistory returns a change handler recording the changes applied by the
+// handler of a module keeping its parameters outside of the params store
+func (k Keeper) WithHistory(subspace string, handler ChangeHandler) ChangeHandler {
+	return historyHandler{k, subspace, handler}
+}
+
+type historyHandler struct {
+	k        Keeper
+	subspace string
+	handler  ChangeHandler
+}
+
+func (h historyHandler) CheckParamChange(key string, value string) sdk.Error {
+	return h.handler.CheckParamChange(key, value)
+}
+
+func (h historyHandler) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	err := h.handler.ApplyParamChange(ctx, key, value)
+	if err != nil {
+		return err
+	}
+	h.k.RecordParamChange(ctx, h.subspace, key, value)
+	return nil
+}
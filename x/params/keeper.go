@@ -0,0 +1,49 @@
+package params
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the global parameter store, which is split into a subspace per module
+type Keeper struct {
+	cdc *wire.Codec
+	key sdk.StoreKey
+
+	spaces map[string]*Subspace
+}
+
+// NewKeeper creates a params keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc:    cdc,
+		key:    key,
+		spaces: make(map[string]*Subspace),
+	}
+}
+
+// Subspace allocates the subspace of a module, the module declares its
+// parameters with WithKeyTable
+func (k Keeper) Subspace(name string) Subspace {
+	if name == "" {
+		panic("cannot use an empty subspace name")
+	}
+	if name[0] == HistoryPrefix[0] {
+		panic("subspace name collides with the parameter history: " + name)
+	}
+	if _, ok := k.spaces[name]; ok {
+		panic("subspace already allocated: " + name)
+	}
+	space := newSubspace(k.cdc, k.key, name)
+	k.spaces[name] = &space
+	return space
+}
+
+// GetSubspace returns an allocated subspace
+func (k Keeper) GetSubspace(name string) (Subspace, bool) {
+	space, ok := k.spaces[name]
+	if !ok {
+		return Subspace{}, false
+	}
+	return *space, true
+}
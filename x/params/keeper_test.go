@@ -0,0 +1,196 @@
+package params
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+type testParams struct {
+	Count int64   `json:"count"`
+	Rate  sdk.Rat `json:"rate"`
+}
+
+var (
+	keyCount = []byte("Count")
+	keyRate  = []byte("Rate")
+)
+
+func (p *testParams) ParamSetPairs() ParamSetPairs {
+	return ParamSetPairs{
+		{keyCount, &p.Count, func(value interface{}) error {
+			if value.(int64) < 0 {
+				return errors.New("negative count")
+			}
+			return nil
+		}},
+		{keyRate, &p.Rate, nil},
+	}
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyParams := sdk.NewKVStoreKey("params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewTMLogger(os.Stdout))
+	cdc := wire.NewCodec()
+	return ctx, NewKeeper(cdc, keyParams)
+}
+
+func TestSubspace(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	// names are unique
+	require.Panics(t, func() { keeper.Subspace("") })
+	space := keeper.Subspace("test")
+	require.Panics(t, func() { keeper.Subspace("test") })
+	require.False(t, space.HasKeyTable())
+
+	// the key table is shared with the subspace held by the keeper
+	space = space.WithKeyTable(NewKeyTable().RegisterParamSet(&testParams{}))
+	require.True(t, space.HasKeyTable())
+	held, found := keeper.GetSubspace("test")
+	require.True(t, found)
+	require.True(t, held.HasKeyTable())
+	require.Panics(t, func() { held.WithKeyTable(NewKeyTable()) })
+	_, found = keeper.GetSubspace("other")
+	require.False(t, found)
+
+	// get and set single parameters
+	require.False(t, space.Has(ctx, keyCount))
+	var count int64
+	require.Panics(t, func() { space.Get(ctx, keyCount, &count) })
+	count = 3
+	space.GetIfExists(ctx, keyCount, &count)
+	require.Equal(t, int64(3), count)
+	space.Set(ctx, keyCount, int64(5))
+	require.True(t, held.Has(ctx, keyCount))
+	held.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(5), count)
+
+	// undeclared keys, wrong types and invalid values are rejected
+	require.Panics(t, func() { space.Set(ctx, []byte("Other"), int64(1)) })
+	require.Panics(t, func() { space.Set(ctx, keyCount, 1) })
+	require.Panics(t, func() { space.Set(ctx, keyCount, int64(-1)) })
+	space.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(5), count)
+
+	// parameter sets
+	params := testParams{7, sdk.NewRat(1, 3)}
+	space.SetParamSet(ctx, &params)
+	var loaded testParams
+	space.GetParamSet(ctx, &loaded)
+	require.Equal(t, params.Count, loaded.Count)
+	require.True(t, params.Rate.Equal(loaded.Rate))
+	require.Panics(t, func() { space.SetParamSet(ctx, &testParams{-1, sdk.OneRat()}) })
+
+	// subspaces don't share their values
+	other := keeper.Subspace("test2").WithKeyTable(NewKeyTable().RegisterParamSet(&testParams{}))
+	require.False(t, other.Has(ctx, keyCount))
+}
+
+func TestParamChange(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	space := keeper.Subspace("test").WithKeyTable(NewKeyTable().RegisterParamSet(&testParams{}))
+
+	tests := []struct {
+		key, value string
+		expectPass bool
+	}{
+		{"Count", `"7"`, true},
+		{"Rate", `"1/3"`, true},
+		{"Count", `"-1"`, false},
+		{"Count", `"seven"`, false},
+		{"Unknown", `"7"`, false},
+	}
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, space.CheckParamChange(tc.key, tc.value), "test: %v", i)
+		} else {
+			require.NotNil(t, space.CheckParamChange(tc.key, tc.value), "test: %v", i)
+			require.NotNil(t, space.ApplyParamChange(ctx, tc.key, tc.value), "test: %v", i)
+		}
+	}
+	require.False(t, space.Has(ctx, keyCount))
+
+	require.Nil(t, space.ApplyParamChange(ctx, "Count", `"7"`))
+	var count int64
+	space.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(7), count)
+}
+
+type testHandler struct {
+	values map[string]string
+}
+
+func (h testHandler) CheckParamChange(key string, value string) sdk.Error {
+	if value == "" {
+		return ErrInvalidParamValue(DefaultCodespace, "module", key, "empty value")
+	}
+	return nil
+}
+
+func (h testHandler) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	err := h.CheckParamChange(key, value)
+	if err != nil {
+		return err
+	}
+	h.values[key] = value
+	return nil
+}
+
+func TestParamHistory(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	space := keeper.Subspace("test").WithKeyTable(NewKeyTable().RegisterParamSet(&testParams{}))
+	require.Panics(t, func() { keeper.Subspace("\x00test") })
+
+	// the initial value, set at genesis, starts the history and setting the
+	// same value again isn't a change
+	space.Set(ctx, keyCount, int64(5))
+	space.Set(ctx, keyCount, int64(5))
+	require.Equal(t, []ParamChangeRecord{
+		{0, NoProposalID, "test", "Count", `"5"`},
+	}, keeper.GetParamHistory(ctx, "test", "Count"))
+
+	ctx = ctx.WithBlockHeight(10)
+	space.Set(ctx, keyCount, int64(6))
+	require.Nil(t, space.ApplyParamChange(WithProposalID(ctx, 3), "Count", `"7"`))
+	ctx = ctx.WithBlockHeight(12)
+	require.Nil(t, space.ApplyParamChange(WithProposalID(ctx, 4), "Count", `"8"`))
+	require.NotNil(t, space.ApplyParamChange(WithProposalID(ctx, 5), "Count", `"-8"`))
+
+	require.Equal(t, []ParamChangeRecord{
+		{0, NoProposalID, "test", "Count", `"5"`},
+		{10, NoProposalID, "test", "Count", `"6"`},
+		{10, 3, "test", "Count", `"7"`},
+		{12, 4, "test", "Count", `"8"`},
+	}, keeper.GetParamHistory(ctx, "test", "Count"))
+
+	// the histories of the keys and subspaces are distinct
+	require.Equal(t, []ParamChangeRecord{}, keeper.GetParamHistory(ctx, "test", "Rate"))
+	require.Equal(t, []ParamChangeRecord{}, keeper.GetParamHistory(ctx, "tes", "tCount"))
+
+	// the changes of parameters kept outside of the params store are recorded
+	// by the handler of the module
+	values := make(map[string]string)
+	handler := keeper.WithHistory("module", testHandler{values})
+	require.NotNil(t, handler.ApplyParamChange(ctx, "Flag", ""))
+	require.Nil(t, handler.ApplyParamChange(WithProposalID(ctx, 6), "Flag", "true"))
+	require.Equal(t, "true", values["Flag"])
+	require.Equal(t, []ParamChangeRecord{
+		{12, 6, "module", "Flag", "true"},
+	}, keeper.GetParamHistory(ctx, "module", "Flag"))
+}
@@ -0,0 +1,184 @@
+package params
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Subspace is the part of the parameter store owned by a module, the values
+// are JSON encoded under keys prefixed by the name of the subspace
+type Subspace struct {
+	cdc   *wire.Codec
+	key   sdk.StoreKey
+	name  []byte
+	table KeyTable
+}
+
+func newSubspace(cdc *wire.Codec, key sdk.StoreKey, name string) Subspace {
+	return Subspace{
+		cdc:   cdc,
+		key:   key,
+		name:  []byte(name),
+		table: KeyTable{make(map[string]attribute)},
+	}
+}
+
+// WithKeyTable declares the parameters of the subspace, it can only be
+// called once as all the copies of the subspace share the key table
+func (s Subspace) WithKeyTable(table KeyTable) Subspace {
+	if table.m == nil {
+		panic("cannot use an uninitialized key table")
+	}
+	if len(s.table.m) != 0 {
+		panic("the key table of the subspace is already set")
+	}
+	for k, v := range table.m {
+		s.table.m[k] = v
+	}
+	return s
+}
+
+// HasKeyTable returns whether the parameters of the subspace are declared
+func (s Subspace) HasKeyTable() bool {
+	return len(s.table.m) != 0
+}
+
+// Name returns the name of the subspace
+func (s Subspace) Name() string {
+	return string(s.name)
+}
+
+func (s Subspace) kvStore(ctx sdk.Context) sdk.KVStore {
+	prefix := make([]byte, 0, len(s.name)+1)
+	prefix = append(append(prefix, s.name...), '/')
+	return ctx.KVStore(s.key).Prefix(prefix)
+}
+
+// Get loads a parameter into ptr, panics if it is not set
+func (s Subspace) Get(ctx sdk.Context, key []byte, ptr interface{}) {
+	bz := s.kvStore(ctx).Get(key)
+	if bz == nil {
+		panic(fmt.Sprintf("parameter %s/%s is not set", s.name, key))
+	}
+	err := s.cdc.UnmarshalJSON(bz, ptr)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetIfExists loads a parameter into ptr, leaving it unchanged if it is not set
+func (s Subspace) GetIfExists(ctx sdk.Context, key []byte, ptr interface{}) {
+	bz := s.kvStore(ctx).Get(key)
+	if bz == nil {
+		return
+	}
+	err := s.cdc.UnmarshalJSON(bz, ptr)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Has returns whether a parameter is set
+func (s Subspace) Has(ctx sdk.Context, key []byte) bool {
+	return s.kvStore(ctx).Has(key)
+}
+
+// Set stores a parameter, panics if the parameter isn't declared in the key
+// table with the type of the value or if the value is invalid. The initial
+// value of a parameter and its changes are appended to its history.
+func (s Subspace) Set(ctx sdk.Context, key []byte, value interface{}) {
+	err := s.checkValue(key, value)
+	if err != nil {
+		panic(err)
+	}
+	bz, err := s.cdc.MarshalJSON(value)
+	if err != nil {
+		panic(err)
+	}
+	store := s.kvStore(ctx)
+	prev := store.Get(key)
+	store.Set(key, bz)
+	if !bytes.Equal(prev, bz) {
+		recordChange(ctx, s.cdc, s.key, s.Name(), string(key), string(bz))
+	}
+}
+
+// check the type of a parameter value and run its validator
+func (s Subspace) checkValue(key []byte, value interface{}) error {
+	attr, ok := s.table.m[string(key)]
+	if !ok {
+		return fmt.Errorf("parameter %s/%s is not declared", s.name, key)
+	}
+	if ty := reflect.TypeOf(value); ty != attr.ty {
+		return fmt.Errorf("parameter %s/%s must be of type %v, got %v", s.name, key, attr.ty, ty)
+	}
+	if attr.vfn == nil {
+		return nil
+	}
+	err := attr.vfn(value)
+	if err != nil {
+		return fmt.Errorf("invalid parameter %s/%s: %v", s.name, key, err)
+	}
+	return nil
+}
+
+// GetParamSet loads all the parameters of a parameter struct
+func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, pair := range ps.ParamSetPairs() {
+		s.Get(ctx, pair.Key, pair.Value)
+	}
+}
+
+// SetParamSet stores all the parameters of a parameter struct, each one
+// being validated as it is set
+func (s Subspace) SetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, pair := range ps.ParamSetPairs() {
+		value := reflect.Indirect(reflect.ValueOf(pair.Value)).Interface()
+		s.Set(ctx, pair.Key, value)
+	}
+}
+
+//______________________________________________________________________
+// changes by governance
+
+// decode a JSON value of a parameter and check it as Set would
+func (s Subspace) decodeValue(key string, value string) (interface{}, sdk.Error) {
+	attr, ok := s.table.m[key]
+	if !ok {
+		return nil, ErrUnknownParam(DefaultCodespace, s.Name(), key)
+	}
+	ptr := reflect.New(attr.ty)
+	err := s.cdc.UnmarshalJSON([]byte(value), ptr.Interface())
+	if err != nil {
+		return nil, ErrInvalidParamValue(DefaultCodespace, s.Name(), key, err.Error())
+	}
+	decoded := ptr.Elem().Interface()
+	if attr.vfn != nil {
+		err = attr.vfn(decoded)
+		if err != nil {
+			return nil, ErrInvalidParamValue(DefaultCodespace, s.Name(), key, err.Error())
+		}
+	}
+	return decoded, nil
+}
+
+// CheckParamChange checks that the key is declared and that the JSON encoded
+// value is a valid value of the parameter
+func (s Subspace) CheckParamChange(key string, value string) sdk.Error {
+	_, err := s.decodeValue(key, value)
+	return err
+}
+
+// ApplyParamChange sets a parameter to a JSON encoded value
+func (s Subspace) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	decoded, err := s.decodeValue(key, value)
+	if err != nil {
+		return err
+	}
+	s.Set(ctx, []byte(key), decoded)
+	return nil
+}
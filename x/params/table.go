@@ -0,0 +1,70 @@
+package params
+
+import (
+	"reflect"
+)
+
+// ValueValidatorFn checks a parameter value before it is set
+type ValueValidatorFn func(value interface{}) error
+
+// ParamSetPair is a parameter key with a pointer to the field holding its
+// value and an optional validator of the value
+type ParamSetPair struct {
+	Key         []byte
+	Value       interface{}
+	ValidatorFn ValueValidatorFn
+}
+
+// ParamSetPairs - slice of parameter pairs
+type ParamSetPairs []ParamSetPair
+
+// ParamSet is implemented by the parameter structs of the modules, it lists
+// the pairs of all the parameters of the struct
+type ParamSet interface {
+	ParamSetPairs() ParamSetPairs
+}
+
+type attribute struct {
+	ty  reflect.Type
+	vfn ValueValidatorFn
+}
+
+// KeyTable declares the parameters of a subspace with their types and validators
+type KeyTable struct {
+	m map[string]attribute
+}
+
+// NewKeyTable creates a key table declaring the given parameters
+func NewKeyTable(pairs ...ParamSetPair) KeyTable {
+	table := KeyTable{make(map[string]attribute)}
+	for _, psp := range pairs {
+		table = table.RegisterType(psp)
+	}
+	return table
+}
+
+// RegisterType declares a parameter, the value of the pair must be a pointer
+func (t KeyTable) RegisterType(psp ParamSetPair) KeyTable {
+	if len(psp.Key) == 0 {
+		panic("cannot register a parameter with an empty key")
+	}
+	keystr := string(psp.Key)
+	if _, ok := t.m[keystr]; ok {
+		panic("parameter key already registered: " + keystr)
+	}
+
+	rty := reflect.TypeOf(psp.Value)
+	if rty.Kind() != reflect.Ptr {
+		panic("parameter value of " + keystr + " must be a pointer")
+	}
+	t.m[keystr] = attribute{rty.Elem(), psp.ValidatorFn}
+	return t
+}
+
+// RegisterParamSet declares all the parameters of a parameter struct
+func (t KeyTable) RegisterParamSet(ps ParamSet) KeyTable {
+	for _, psp := range ps.ParamSetPairs() {
+		t = t.RegisterType(psp)
+	}
+	return t
+}
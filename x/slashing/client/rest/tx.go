@@ -30,6 +30,7 @@ type UnrevokeBody struct {
 	ChainID          string `json:"chain_id"`
 	AccountNumber    int64  `json:"account_number"`
 	Sequence         int64  `json:"sequence"`
+	TimeoutHeight    int64  `json:"timeout_height"`
 	Gas              int64  `json:"gas"`
 	ValidatorAddr    string `json:"validator_addr"`
 }
@@ -74,6 +75,7 @@ func unrevokeRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		ctx = ctx.WithChainID(m.ChainID)
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 
 		msg := slashing.NewMsgUnrevoke(validatorAddr)
 
@@ -14,6 +14,7 @@ const (
 
 	CodeInvalidValidator CodeType = 101
 	CodeValidatorJailed  CodeType = 102
+	CodeSelfDelegation   CodeType = 103
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -25,3 +26,6 @@ func ErrBadValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
 func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorJailed, "validator jailed, cannot yet be unrevoked")
 }
+func ErrSelfDelegationTooLow(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfDelegation, "validator self-delegation below the minimum, cannot be unrevoked")
+}
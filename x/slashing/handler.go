@@ -47,8 +47,11 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 	info.StartHeight = ctx.BlockHeight()
 	k.setValidatorSigningInfo(ctx, addr, info)
 
-	// Unrevoke the validator
+	// Unrevoke the validator, which stays revoked without its minimum self-delegation
 	k.validatorSet.Unrevoke(ctx, validator.GetPubKey())
+	if k.validatorSet.Validator(ctx, msg.ValidatorAddr).GetRevoked() {
+		return ErrSelfDelegationTooLow(k.codespace).Result()
+	}
 
 	tags := sdk.NewTags("action", []byte("unrevoke"), "validator", []byte(msg.ValidatorAddr.String()))
 
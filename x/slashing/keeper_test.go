@@ -11,15 +11,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
-// Have to change these parameters for tests
-// lest the tests take forever
-func init() {
-	SignedBlocksWindow = 1000
-	MinSignedPerWindow = SignedBlocksWindow / 2
-	DowntimeUnbondDuration = 60 * 60
-	DoubleSignUnbondDuration = 60 * 60
-}
-
 // Test that a validator is slashed correctly
 // when we discover evidence of infraction
 func TestHandleDoubleSign(t *testing.T) {
@@ -46,7 +37,7 @@ func TestHandleDoubleSign(t *testing.T) {
 	sk.Unrevoke(ctx, val)
 	// power should be reduced
 	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
-	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + MaxEvidenceAge})
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + keeper.MaxEvidenceAge(ctx)})
 
 	// double sign past max age
 	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
@@ -75,26 +66,28 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, int64(0), info.SignedBlocksCounter)
 	require.Equal(t, int64(0), info.JailedUntil)
 	height := int64(0)
+	signedBlocksWindow := keeper.SignedBlocksWindow(ctx)
+	minSignedPerWindow := keeper.MinSignedPerWindow(ctx)
 
 	// 1000 first blocks OK
-	for ; height < SignedBlocksWindow; height++ {
+	for ; height < signedBlocksWindow; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, true)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow, info.SignedBlocksCounter)
 
 	// 500 blocks missed
-	for ; height < SignedBlocksWindow+(SignedBlocksWindow-MinSignedPerWindow); height++ {
+	for ; height < signedBlocksWindow+(signedBlocksWindow-minSignedPerWindow); height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow, info.SignedBlocksCounter)
 
 	// validator should be bonded still
 	validator, _ := sk.GetValidatorByPubKey(ctx, val)
@@ -108,7 +101,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow-1, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow-1, info.SignedBlocksCounter)
 
 	// validator should have been revoked
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
@@ -119,7 +112,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.False(t, got.IsOK())
 
 	// unrevocation should succeed after jail expiration
-	ctx = ctx.WithBlockHeader(abci.Header{Time: DowntimeUnbondDuration + 1})
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.DowntimeUnbondDuration(ctx) + 1})
 	got = slh(ctx, NewMsgUnrevoke(addr))
 	require.True(t, got.IsOK())
 
@@ -135,7 +128,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, height, info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-MinSignedPerWindow-1, info.SignedBlocksCounter)
+	require.Equal(t, signedBlocksWindow-minSignedPerWindow-1, info.SignedBlocksCounter)
 
 	// validator should not be immediately revoked again
 	height++
@@ -145,14 +138,14 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, sdk.Bonded, validator.GetStatus())
 
 	// 500 signed blocks
-	nextHeight := height + MinSignedPerWindow + 1
+	nextHeight := height + minSignedPerWindow + 1
 	for ; height < nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
 	}
 
 	// validator should be revoked again after 500 unsigned blocks
-	nextHeight = height + MinSignedPerWindow + 1
+	nextHeight = height + minSignedPerWindow + 1
 	for ; height <= nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
@@ -176,16 +169,17 @@ func TestHandleNewValidator(t *testing.T) {
 	require.Equal(t, sdk.NewRat(amt), sk.Validator(ctx, addr).GetPower())
 
 	// 1000 first blocks not a validator
-	ctx = ctx.WithBlockHeight(SignedBlocksWindow + 1)
+	signedBlocksWindow := keeper.SignedBlocksWindow(ctx)
+	ctx = ctx.WithBlockHeight(signedBlocksWindow + 1)
 
 	// Now a validator, for two blocks
 	keeper.handleValidatorSignature(ctx, val, 100, true)
-	ctx = ctx.WithBlockHeight(SignedBlocksWindow + 2)
+	ctx = ctx.WithBlockHeight(signedBlocksWindow + 2)
 	keeper.handleValidatorSignature(ctx, val, 100, false)
 
 	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
-	require.Equal(t, int64(SignedBlocksWindow+1), info.StartHeight)
+	require.Equal(t, signedBlocksWindow+1, info.StartHeight)
 	require.Equal(t, int64(2), info.IndexOffset)
 	require.Equal(t, int64(1), info.SignedBlocksCounter)
 	require.Equal(t, int64(0), info.JailedUntil)
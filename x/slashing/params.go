@@ -1,42 +1,154 @@
 package slashing
 
 import (
+	"errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
+// default paramspace for the slashing params
+const DefaultParamspace = "slashing"
+
+// keys of the params in the paramspace
 var (
-	// MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
-	// TODO Should this be a governance parameter or just modifiable with SoftwareUpgradeProposals?
-	// MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
-	// TODO Temporarily set to 2 minutes for testnets.
-	MaxEvidenceAge int64 = 60 * 2
-
-	// SignedBlocksWindow - sliding window for downtime slashing
-	// TODO Governance parameter?
-	// TODO Temporarily set to 40000 blocks for testnets
-	SignedBlocksWindow int64 = 40000
-
-	// Downtime slashing threshold - 50%
-	// TODO Governance parameter?
-	MinSignedPerWindow = SignedBlocksWindow / 2
-
-	// Downtime unbond duration
-	// TODO Governance parameter?
-	// TODO Temporarily set to five minutes for testnets
-	DowntimeUnbondDuration int64 = 60 * 5
-
-	// Double-sign unbond duration
-	// TODO Governance parameter?
-	// TODO Temporarily set to five minutes for testnets
-	DoubleSignUnbondDuration int64 = 60 * 5
+	KeyMaxEvidenceAge           = []byte("MaxEvidenceAge")
+	KeySignedBlocksWindow       = []byte("SignedBlocksWindow")
+	KeyMinSignedPerWindow       = []byte("MinSignedPerWindow")
+	KeyDoubleSignUnbondDuration = []byte("DoubleSignUnbondDuration")
+	KeyDowntimeUnbondDuration   = []byte("DowntimeUnbondDuration")
+	KeySlashFractionDoubleSign  = []byte("SlashFractionDoubleSign")
+	KeySlashFractionDowntime    = []byte("SlashFractionDowntime")
 )
 
-var (
-	// SlashFractionDoubleSign - currently 5%
-	// TODO Governance parameter?
-	SlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
+// Params - used for slashing, settable by governance
+type Params struct {
+	MaxEvidenceAge           int64   `json:"max_evidence_age"`            // max age in seconds of double sign evidence
+	SignedBlocksWindow       int64   `json:"signed_blocks_window"`        // sliding window for downtime slashing
+	MinSignedPerWindow       sdk.Rat `json:"min_signed_per_window"`       // fraction of the window which must be signed
+	DoubleSignUnbondDuration int64   `json:"double_sign_unbond_duration"` // jail duration in seconds after double signing
+	DowntimeUnbondDuration   int64   `json:"downtime_unbond_duration"`    // jail duration in seconds after downtime
+	SlashFractionDoubleSign  sdk.Rat `json:"slash_fraction_double_sign"`  // fraction of the stake slashed for double signing
+	SlashFractionDowntime    sdk.Rat `json:"slash_fraction_downtime"`     // fraction of the stake slashed for downtime
+}
 
-	// SlashFractionDowntime - currently 1%
-	// TODO Governance parameter?
-	SlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
-)
+// DefaultParams returns the default slashing params
+func DefaultParams() Params {
+	return Params{
+		// TODO Temporarily set to 2 minutes for testnets.
+		MaxEvidenceAge: 60 * 2,
+
+		// TODO Temporarily set to 40000 blocks for testnets
+		SignedBlocksWindow: 40000,
+
+		// Downtime slashing threshold - 50%
+		MinSignedPerWindow: sdk.NewRat(1, 2),
+
+		// TODO Temporarily set to five minutes for testnets
+		DoubleSignUnbondDuration: 60 * 5,
+		DowntimeUnbondDuration:   60 * 5,
+
+		// currently 5%
+		SlashFractionDoubleSign: sdk.NewRat(1, 20),
+
+		// currently 1%
+		SlashFractionDowntime: sdk.NewRat(1, 100),
+	}
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyMaxEvidenceAge, &p.MaxEvidenceAge, validateNonNegative},
+		{KeySignedBlocksWindow, &p.SignedBlocksWindow, validatePositive},
+		{KeyMinSignedPerWindow, &p.MinSignedPerWindow, validateFraction},
+		{KeyDoubleSignUnbondDuration, &p.DoubleSignUnbondDuration, validateNonNegative},
+		{KeyDowntimeUnbondDuration, &p.DowntimeUnbondDuration, validateNonNegative},
+		{KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign, validateFraction},
+		{KeySlashFractionDowntime, &p.SlashFractionDowntime, validateFraction},
+	}
+}
+
+// ParamKeyTable declares the slashing params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func validateNonNegative(value interface{}) error {
+	if value.(int64) < 0 {
+		return errors.New("cannot be negative")
+	}
+	return nil
+}
+
+func validatePositive(value interface{}) error {
+	if value.(int64) <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func validateFraction(value interface{}) error {
+	rat := value.(sdk.Rat)
+	if rat.LT(sdk.ZeroRat()) || rat.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// GetParams returns the slashing params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
+// SetParams sets the slashing params
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// MaxEvidenceAge - max age for evidence
+func (k Keeper) MaxEvidenceAge(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyMaxEvidenceAge, &res)
+	return
+}
+
+// SignedBlocksWindow - sliding window for downtime slashing
+func (k Keeper) SignedBlocksWindow(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeySignedBlocksWindow, &res)
+	return
+}
+
+// MinSignedPerWindow - downtime slashing threshold in blocks
+func (k Keeper) MinSignedPerWindow(ctx sdk.Context) int64 {
+	var minSignedPerWindow sdk.Rat
+	k.paramspace.Get(ctx, KeyMinSignedPerWindow, &minSignedPerWindow)
+	signedBlocksWindow := k.SignedBlocksWindow(ctx)
+	return sdk.NewRat(signedBlocksWindow).Mul(minSignedPerWindow).RoundInt64()
+}
+
+// DoubleSignUnbondDuration - double-sign unbond duration
+func (k Keeper) DoubleSignUnbondDuration(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyDoubleSignUnbondDuration, &res)
+	return
+}
+
+// DowntimeUnbondDuration - downtime unbond duration
+func (k Keeper) DowntimeUnbondDuration(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, KeyDowntimeUnbondDuration, &res)
+	return
+}
+
+// SlashFractionDoubleSign - fraction of the stake slashed for double signing
+func (k Keeper) SlashFractionDoubleSign(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeySlashFractionDoubleSign, &res)
+	return
+}
+
+// SlashFractionDowntime - fraction of the stake slashed for downtime
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (res sdk.Rat) {
+	k.paramspace.Get(ctx, KeySlashFractionDowntime, &res)
+	return
+}
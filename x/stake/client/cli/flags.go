@@ -19,6 +19,12 @@ const (
 	FlagIdentity = "keybase-sig"
 	FlagWebsite  = "website"
 	FlagDetails  = "details"
+
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxRate       = "commission-max-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+
+	FlagMinSelfDelegation = "min-self-delegation"
 )
 
 // common flagsets to add to various functions
@@ -27,6 +33,7 @@ var (
 	fsAmount       = flag.NewFlagSet("", flag.ContinueOnError)
 	fsShares       = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDescription  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommission   = flag.NewFlagSet("", flag.ContinueOnError)
 	fsValidator    = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDelegator    = flag.NewFlagSet("", flag.ContinueOnError)
 	fsRedelegation = flag.NewFlagSet("", flag.ContinueOnError)
@@ -41,6 +48,9 @@ func init() {
 	fsDescription.String(FlagIdentity, "[do-not-modify]", "optional keybase signature")
 	fsDescription.String(FlagWebsite, "[do-not-modify]", "optional website")
 	fsDescription.String(FlagDetails, "[do-not-modify]", "optional details")
+	fsCommission.String(FlagCommissionRate, "0", "commission rate charged to delegators, as a decimal")
+	fsCommission.String(FlagCommissionMaxRate, "0", "maximum commission rate the validator can ever charge, as a decimal")
+	fsCommission.String(FlagCommissionMaxChangeRate, "0", "maximum daily change of the commission rate, as a decimal")
 	fsValidator.String(FlagAddressValidator, "", "hex address of the validator")
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
 	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
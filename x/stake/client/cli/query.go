@@ -105,6 +105,56 @@ func GetCmdQueryValidators(storeName string, cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
+// get the command to query the tokens the owner of a validator keeps
+// self-delegated, next to its minimum self-delegation
+func GetCmdQuerySelfBond(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "self-bond [owner-addr]",
+		Short: "Query the tokens self-delegated by the owner of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			addr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(stake.GetValidatorKey(addr), storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no validator found with owner %s", args[0])
+			}
+			var validator stake.Validator
+			cdc.MustUnmarshalBinary(res, &validator)
+
+			res, err = ctx.QueryStore(stake.PoolKey, storeName)
+			if err != nil {
+				return err
+			}
+			var pool stake.Pool
+			cdc.MustUnmarshalBinary(res, &pool)
+			selfBond := validator.SelfBond(pool)
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				fmt.Printf("Self Bond: %s\n", selfBond.Tokens.FloatString())
+				fmt.Printf("Min Self Delegation: %s\n", selfBond.MinSelfDelegation.String())
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, selfBond)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
 // get the command to query a single delegation
 func GetCmdQueryDelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -49,7 +49,24 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description)
+			commission, err := sdk.NewRatFromDecimal(viper.GetString(FlagCommissionRate), types.MaxBondDenominatorPrecision)
+			if err != nil {
+				return err
+			}
+			commissionMax, err := sdk.NewRatFromDecimal(viper.GetString(FlagCommissionMaxRate), types.MaxBondDenominatorPrecision)
+			if err != nil {
+				return err
+			}
+			commissionChangeRate, err := sdk.NewRatFromDecimal(viper.GetString(FlagCommissionMaxChangeRate), types.MaxBondDenominatorPrecision)
+			if err != nil {
+				return err
+			}
+			minSelfDelegation, ok := sdk.NewIntFromString(viper.GetString(FlagMinSelfDelegation))
+			if !ok {
+				return fmt.Errorf("minimum self-delegation must be an integer")
+			}
+			msg := stake.NewMsgCreateValidatorWithCommission(validatorAddr, pk, amount, description,
+				commission, commissionMax, commissionChangeRate, minSelfDelegation)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -65,7 +82,9 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsPk)
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().AddFlagSet(fsCommission)
 	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().String(FlagMinSelfDelegation, "1", "minimum amount of tokens the validator must keep self-delegated")
 	return cmd
 }
 
@@ -86,7 +105,17 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgEditValidator(validatorAddr, description)
+
+			// only change the commission if a new rate is given
+			var commission *sdk.Rat
+			if commissionStr := viper.GetString(FlagCommissionRate); commissionStr != "" {
+				rate, err := sdk.NewRatFromDecimal(commissionStr, types.MaxBondDenominatorPrecision)
+				if err != nil {
+					return err
+				}
+				commission = &rate
+			}
+			msg := stake.NewMsgEditValidator(validatorAddr, description, commission)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
@@ -102,6 +131,7 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().String(FlagCommissionRate, "", "new commission rate charged to delegators, as a decimal")
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
@@ -35,6 +35,11 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 		"/stake/validators",
 		validatorsHandlerFn(ctx, cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/self_bond",
+		selfBondHandlerFn(ctx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query a delegation
@@ -230,10 +235,10 @@ type StakeValidatorOutput struct {
 	BondIntraTxCounter int16             `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins         `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission            sdk.Rat `json:"commission"`              // the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Rat `json:"commission_max"`          // maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // maximum daily change of the validator commission
+	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // commission rate change today, reset each day
 
 	// fee related
 	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
@@ -315,3 +320,61 @@ func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 		w.Write(output)
 	}
 }
+
+// http request handler to query the tokens the owner of a validator keeps
+// self-delegated, next to its minimum self-delegation
+func selfBondHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		validatorAddr, err := sdk.GetValAddressBech32(mux.Vars(r)["validator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := ctx.QueryStore(stake.GetValidatorKey(validatorAddr), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query validator. Error: %s", err.Error())))
+			return
+		}
+
+		// the query will return empty if there is no validator
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var validator stake.Validator
+		err = cdc.UnmarshalBinary(res, &validator)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't decode validator. Error: %s", err.Error())))
+			return
+		}
+
+		res, err = ctx.QueryStore(stake.PoolKey, storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query pool. Error: %s", err.Error())))
+			return
+		}
+		var pool stake.Pool
+		err = cdc.UnmarshalBinary(res, &pool)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't decode pool. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(validator.SelfBond(pool))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
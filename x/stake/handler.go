@@ -1,6 +1,9 @@
 package stake
 
 import (
+	"bytes"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
 	"github.com/cosmos/cosmos-sdk/x/stake/tags"
@@ -43,9 +46,53 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 		pool = k.ProcessProvisions(ctx)
 	}
 
+	// allow the validators to change their commission again each day
+	if blockTime-pool.DateLastCommissionReset >= 60*60*24 {
+		pool.DateLastCommissionReset = blockTime
+		k.ResetCommissionChangesToday(ctx)
+	}
+
 	// save the params
 	k.SetPool(ctx, pool)
 
+	// complete the unbonding delegations and redelegations which have matured,
+	// the entries already completed by message or replaced by a later one are skipped.
+	// An entry failing to complete is logged and queued again, to be retried in
+	// the next block or completed by message.
+	logger := ctx.Logger().With("module", "x/stake")
+	matureUnbonds := k.DequeueAllMatureUBDQueue(ctx, blockTime)
+	for _, dvPair := range matureUnbonds {
+		ubd, found := k.GetUnbondingDelegation(ctx, dvPair.DelegatorAddr, dvPair.ValidatorAddr)
+		if !found || ubd.MinTime > blockTime {
+			continue
+		}
+		cacheCtx, write := ctx.CacheContext()
+		err := k.CompleteUnbonding(cacheCtx, dvPair.DelegatorAddr, dvPair.ValidatorAddr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to complete the unbonding of %v from %v: %v",
+				dvPair.DelegatorAddr, dvPair.ValidatorAddr, err.ABCILog()))
+			k.InsertUBDQueue(ctx, ubd)
+			continue
+		}
+		write()
+	}
+	matureRedelegations := k.DequeueAllMatureRedelegationQueue(ctx, blockTime)
+	for _, dvvTriplet := range matureRedelegations {
+		red, found := k.GetRedelegation(ctx, dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr)
+		if !found || red.MinTime > blockTime {
+			continue
+		}
+		cacheCtx, write := ctx.CacheContext()
+		err := k.CompleteRedelegation(cacheCtx, dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to complete the redelegation of %v from %v to %v: %v",
+				dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr, err.ABCILog()))
+			k.InsertRedelegationQueue(ctx, red)
+			continue
+		}
+		write()
+	}
+
 	// reset the intra-transaction counter
 	k.SetIntraTxCounter(ctx, 0)
 
@@ -72,6 +119,10 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	}
 
 	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, msg.Description)
+	validator.Commission = msg.Commission
+	validator.CommissionMax = msg.CommissionMax
+	validator.CommissionChangeRate = msg.CommissionChangeRate
+	validator.MinSelfDelegation = msg.MinSelfDelegation
 	k.SetValidator(ctx, validator)
 	k.SetValidatorByPubKeyIndex(ctx, validator)
 
@@ -102,18 +153,28 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	}
 
 	// replace all editable fields (clients should autofill existing values)
-	description, err := validator.Description.UpdateDescription(msg.Description)
-	if err != nil {
-		return err.Result()
+	if msg.Description != (Description{}) {
+		description, err := validator.Description.UpdateDescription(msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		validator.Description = description
+	}
+
+	if msg.Commission != nil {
+		var err sdk.Error
+		validator, err = validator.UpdateCommission(*msg.Commission)
+		if err != nil {
+			return err.Result()
+		}
 	}
-	validator.Description = description
 
 	k.UpdateValidator(ctx, validator)
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionEditValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
@@ -129,7 +190,8 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	if msg.Bond.Denom != k.GetParams(ctx).BondDenom {
 		return ErrBadDenom(k.Codespace()).Result()
 	}
-	if validator.Revoked == true {
+	// only the owner may delegate to a revoked validator, to restore its self-delegation
+	if validator.Revoked == true && !bytes.Equal(msg.DelegatorAddr, validator.Owner) {
 		return ErrValidatorRevoked(k.Codespace()).Result()
 	}
 	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Bond, validator)
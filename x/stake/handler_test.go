@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"bytes"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +10,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	keep "github.com/cosmos/cosmos-sdk/x/stake/keeper"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
@@ -16,10 +19,14 @@ import (
 
 func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt int64) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:    Description{},
-		ValidatorAddr:  address,
-		PubKey:         pubKey,
-		SelfDelegation: sdk.Coin{"steak", sdk.NewInt(amt)},
+		Description:          Description{},
+		ValidatorAddr:        address,
+		PubKey:               pubKey,
+		SelfDelegation:       sdk.Coin{"steak", sdk.NewInt(amt)},
+		Commission:           sdk.ZeroRat(),
+		CommissionMax:        sdk.ZeroRat(),
+		CommissionChangeRate: sdk.ZeroRat(),
+		MinSelfDelegation:    sdk.OneInt(),
 	}
 }
 
@@ -642,3 +649,433 @@ func TestBondUnbondRedelegateSlashTwice(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, sdk.NewRat(0), validator.GetPower())
 }
+
+func TestEditValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := keep.Addrs[0]
+
+	// the commission rates are set at creation
+	msgCreateValidator := NewMsgCreateValidatorWithCommission(validatorAddr, keep.PKs[0], sdk.NewCoin("steak", 10),
+		Description{Moniker: "moniker"}, sdk.NewRat(1, 10), sdk.NewRat(5, 10), sdk.NewRat(1, 10), sdk.OneInt())
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 10), validator.Commission))
+	require.True(sdk.RatEq(t, sdk.NewRat(5, 10), validator.CommissionMax))
+	require.True(sdk.RatEq(t, sdk.NewRat(1, 10), validator.CommissionChangeRate))
+
+	// the commission can change within the daily change rate
+	commission := sdk.NewRat(15, 100)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+	require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(sdk.RatEq(t, commission, validator.Commission))
+	require.True(sdk.RatEq(t, sdk.NewRat(5, 100), validator.CommissionChangeToday))
+	require.Equal(t, "moniker", validator.Description.Moniker)
+
+	// decreases count against the daily change rate too
+	commission = sdk.NewRat(5, 100)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+	require.False(t, got.IsOK(), "expected edit-validator to fail beyond the daily change rate")
+	commission = sdk.NewRat(1, 10)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+	require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+	commission = sdk.NewRat(11, 100)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+	require.False(t, got.IsOK(), "expected edit-validator to fail beyond the daily change rate")
+
+	// the changes of the day are reset a day later
+	header := ctx.BlockHeader()
+	header.Time += 60 * 60 * 24
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.CommissionChangeToday.IsZero())
+	require.Equal(t, header.Time, keeper.GetPool(ctx).DateLastCommissionReset)
+	commission = sdk.NewRat(2, 10)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+	require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+
+	// but the commission can never exceed the max rate
+	for i := 0; i < 4; i++ {
+		header.Time += 60 * 60 * 24
+		ctx = ctx.WithBlockHeader(header)
+		EndBlocker(ctx, keeper)
+		commission = commission.Add(sdk.NewRat(1, 10))
+		got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &commission), keeper)
+		if i < 3 {
+			require.True(t, got.IsOK(), "expected edit-validator to be ok, got %v", got)
+		} else {
+			require.False(t, got.IsOK(), "expected edit-validator to fail above the max rate")
+		}
+	}
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(sdk.RatEq(t, sdk.NewRat(5, 10), validator.Commission))
+}
+
+func TestUnbondingQueue(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, del1, del2 := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	// create the validator and delegate to it
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(del1, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(del2, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+
+	// the first delegator begins unbonding at time 0, the second at time 3
+	origHeader := ctx.BlockHeader()
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(del1, validatorAddr, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	header := origHeader
+	header.Time += 3
+	ctx = ctx.WithBlockHeader(header)
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(del2, validatorAddr, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	// nothing matures at time 6
+	header = origHeader
+	header.Time += 6
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetUnbondingDelegation(ctx, del1, validatorAddr)
+	require.True(t, found)
+	_, found = keeper.GetUnbondingDelegation(ctx, del2, validatorAddr)
+	require.True(t, found)
+
+	// the first unbonding completes at time 7 without any message
+	header = origHeader
+	header.Time += 7
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, del1, validatorAddr)
+	require.False(t, found)
+	require.Equal(t, int64(1000), accMapper.GetAccount(ctx, del1).GetCoins().AmountOf(denom).Int64())
+	_, found = keeper.GetUnbondingDelegation(ctx, del2, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, int64(990), accMapper.GetAccount(ctx, del2).GetCoins().AmountOf(denom).Int64())
+
+	// the second unbonding may still be completed by message,
+	// after which the queue entry is skipped
+	header = origHeader
+	header.Time += 10
+	ctx = ctx.WithBlockHeader(header)
+	got = handleMsgCompleteUnbonding(ctx, NewMsgCompleteUnbonding(del2, validatorAddr), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
+	require.Equal(t, int64(1000), accMapper.GetAccount(ctx, del2).GetCoins().AmountOf(denom).Int64())
+}
+
+func TestUnbondingQueueFailure(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, del := keep.Addrs[0], keep.Addrs[1]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(del, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(del, validatorAddr, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	// empty the module account so that the unbonding can't be paid out
+	moduleAcc := accMapper.GetAccount(ctx, auth.NewModuleAddress(ModuleName))
+	moduleCoins := moduleAcc.GetCoins()
+	moduleAcc.SetCoins(sdk.Coins{})
+	accMapper.SetAccount(ctx, moduleAcc)
+
+	// the failing unbonding is left in place instead of halting the chain
+	origHeader := ctx.BlockHeader()
+	header := origHeader
+	header.Time += 7
+	ctx = ctx.WithBlockHeader(header)
+	require.NotPanics(t, func() { EndBlocker(ctx, keeper) })
+	_, found := keeper.GetUnbondingDelegation(ctx, del, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, int64(990), accMapper.GetAccount(ctx, del).GetCoins().AmountOf(denom).Int64())
+
+	// and completes in a later block
+	moduleAcc.SetCoins(moduleCoins)
+	accMapper.SetAccount(ctx, moduleAcc)
+	header.Time++
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, del, validatorAddr)
+	require.False(t, found)
+	require.Equal(t, int64(1000), accMapper.GetAccount(ctx, del).GetCoins().AmountOf(denom).Int64())
+}
+
+func TestRedelegationQueue(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2, validatorAddr3 := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	// create the validators
+	for i, addr := range []sdk.Address{validatorAddr, validatorAddr2, validatorAddr3} {
+		msgCreateValidator := newTestMsgCreateValidator(addr, keep.PKs[i], 10)
+		got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+		require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	}
+
+	// begin redelegate
+	msgBeginRedelegate := NewMsgBeginRedelegate(validatorAddr, validatorAddr, validatorAddr2, sdk.NewRat(10))
+	got := handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the redelegation is still in flight at time 6
+	origHeader := ctx.BlockHeader()
+	header := origHeader
+	header.Time += 6
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+
+	// cannot redelegation to next validator while first redelegation exists
+	msgBeginRedelegate = NewMsgBeginRedelegate(validatorAddr, validatorAddr2, validatorAddr3, sdk.NewRat(10))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, !got.IsOK(), "expected an error, msg: %v", msgBeginRedelegate)
+
+	// the redelegation completes at time 7 without any message
+	header = origHeader
+	header.Time += 7
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found)
+
+	// now should be able to redelegate from the second validator to the third
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error")
+}
+
+func TestSlashInFlightUnbonding(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	valA, del := keep.Addrs[0], keep.Addrs[1]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(valA, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(del, valA, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+
+	// begin unbonding all the stake in the block of the infraction
+	ctx = ctx.WithBlockHeight(1)
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(del, valA, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgBeginUnbonding")
+
+	// slash the validator by half while the unbonding is queued
+	origHeader := ctx.BlockHeader()
+	header := origHeader
+	header.Height = 2
+	header.Time += 3
+	ctx = ctx.WithBlockHeader(header)
+	keeper.Slash(ctx, keep.PKs[0], 1, 20, sdk.NewRat(1, 2))
+	unbonding, found := keeper.GetUnbondingDelegation(ctx, del, valA)
+	require.True(t, found)
+	require.Equal(t, int64(5), unbonding.Balance.Amount.Int64())
+
+	// the slashed balance is paid out once the unbonding matures
+	header = origHeader
+	header.Height = 3
+	header.Time += 7
+	ctx = ctx.WithBlockHeader(header)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, del, valA)
+	require.False(t, found)
+	require.Equal(t, int64(995), accMapper.GetAccount(ctx, del).GetCoins().AmountOf(denom).Int64())
+}
+
+func TestMinSelfDelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2, delegatorAddr := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
+	_ = setInstantUnbondPeriod(keeper, ctx)
+
+	// create the validators, the first one must keep 5 tokens self-delegated
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.MinSelfDelegation = sdk.NewInt(5)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgCreateValidator = newTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 10)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// the self-delegation is tracked separately from other delegations
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewRat(10), validator.SelfDelegationShares)
+	require.Equal(t, sdk.NewRat(20), validator.DelegatorShares)
+
+	// other delegators do not affect the self-delegation
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.False(t, validator.Revoked)
+
+	// unbonding down to the minimum keeps the validator unrevoked
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(5)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.Equal(t, sdk.NewRat(5), validator.SelfDelegationShares)
+	require.False(t, validator.Revoked)
+
+	// redelegating below the minimum revokes the validator
+	msgBeginRedelegate := NewMsgBeginRedelegate(validatorAddr, validatorAddr, validatorAddr2, sdk.NewRat(1))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.Equal(t, sdk.NewRat(4), validator.SelfDelegationShares)
+	require.True(t, validator.Revoked)
+
+	// the validator cannot be unrevoked until the self-delegation is restored
+	keeper.Unrevoke(ctx, keep.PKs[0])
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.Revoked)
+
+	// other delegators cannot delegate to the revoked validator, the owner can
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 1), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(validatorAddr, validatorAddr, 1), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	keeper.Unrevoke(ctx, keep.PKs[0])
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.False(t, validator.Revoked)
+
+	// unbonding below the minimum revokes the validator
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(1)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.Revoked)
+}
+
+func TestMaxPowerChangePerBlock(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	valA, valB, valC, del := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2], keep.Addrs[3]
+
+	// at most a tenth of the voting power may change in a block
+	params := keeper.GetParams(ctx)
+	params.MaxPowerChange = sdk.NewRat(1, 10)
+	keeper.SetParams(ctx, params)
+
+	for i, addr := range []sdk.Address{valA, valB, valC} {
+		msgCreateValidator := newTestMsgCreateValidator(addr, keep.PKs[i], 100)
+		got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+		require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	}
+	got := handleMsgDelegate(ctx, newTestMsgDelegate(del, valA, 100), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+
+	// the initial validator set is reported at once
+	updates := EndBlocker(ctx, keeper)
+	require.Equal(t, 3, len(updates))
+	require.Equal(t, int64(400), keeper.GetLastTotalPower(ctx))
+
+	// a whale redelegates half of the total power
+	msgBeginRedelegate := NewMsgBeginRedelegate(del, valA, valB, sdk.NewRat(100))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the change of 200 is spread over several blocks, each changing at most
+	// a tenth of the power reported before it
+	blocks := 0
+	for {
+		blocks++
+		require.True(t, blocks <= 10, "power change not applied after 10 blocks")
+		ctx = ctx.WithBlockHeight(int64(blocks))
+		totalPower := keeper.GetLastTotalPower(ctx)
+		powerA, powerB := keeper.GetLastValidatorPower(ctx, valA), keeper.GetLastValidatorPower(ctx, valB)
+		updates = EndBlocker(ctx, keeper)
+		if len(updates) == 0 {
+			break
+		}
+		changeA := powerA - keeper.GetLastValidatorPower(ctx, valA)
+		changeB := keeper.GetLastValidatorPower(ctx, valB) - powerB
+		require.True(t, changeA >= 0 && changeB >= 0, "block %d", blocks)
+		require.True(t, changeA+changeB <= totalPower/10, "block %d", blocks)
+	}
+	require.True(t, blocks > 5)
+	require.Equal(t, int64(100), keeper.GetLastValidatorPower(ctx, valA))
+	require.Equal(t, int64(200), keeper.GetLastValidatorPower(ctx, valB))
+	require.Equal(t, int64(100), keeper.GetLastValidatorPower(ctx, valC))
+
+	// nothing is left to report
+	updates = EndBlocker(ctx, keeper)
+	require.Equal(t, 0, len(updates))
+}
+
+func TestMaxPowerChangeUnreportedValidator(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	addrs := []sdk.Address{keep.Addrs[0], keep.Addrs[1], keep.Addrs[2], keep.Addrs[3]}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i], addrs[j]) < 0 })
+	valA, valB, valC, valD, del := addrs[0], addrs[1], addrs[2], addrs[3], keep.Addrs[4]
+
+	params := keeper.GetParams(ctx)
+	params.MaxPowerChange = sdk.NewRat(1, 10)
+	keeper.SetParams(ctx, params)
+
+	for i, addr := range []sdk.Address{valA, valB, valC} {
+		msgCreateValidator := newTestMsgCreateValidator(addr, keep.PKs[i], 100)
+		got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+		require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	}
+	got := handleMsgDelegate(ctx, newTestMsgDelegate(del, valA, 100), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+	updates := EndBlocker(ctx, keeper)
+	require.Equal(t, 3, len(updates))
+
+	// a redelegation uses up the cap of the block before the update of the
+	// validator bonding under the cap, which is deferred
+	got = handleMsgBeginRedelegate(ctx, NewMsgBeginRedelegate(del, valA, valB, sdk.NewRat(100)), keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	got = handleMsgCreateValidator(ctx, newTestMsgCreateValidator(valD, keep.PKs[3], 10), keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	ctx = ctx.WithBlockHeight(1)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, int64(0), keeper.GetLastValidatorPower(ctx, valD))
+
+	// it unbonds before being reported, so its removal is never reported
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(valD, valD, sdk.NewRat(10)), keeper)
+	require.True(t, got.IsOK(), "expected no error")
+	pubKeyD := NewValidator(valD, keep.PKs[3], Description{}).ABCIValidatorZero().PubKey
+	for blocks := int64(2); ; blocks++ {
+		require.True(t, blocks <= 12, "power change not applied after 10 blocks")
+		ctx = ctx.WithBlockHeight(blocks)
+		updates = EndBlocker(ctx, keeper)
+		if len(updates) == 0 {
+			break
+		}
+		for _, update := range updates {
+			require.NotEqual(t, pubKeyD, update.PubKey, "block %d", blocks)
+		}
+	}
+	require.Equal(t, int64(0), keeper.GetLastValidatorPower(ctx, valD))
+	require.Equal(t, int64(400), keeper.GetLastTotalPower(ctx))
+}
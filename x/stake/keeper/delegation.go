@@ -132,6 +132,47 @@ func (k Keeper) RemoveUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDe
 	store.Delete(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc))
 }
 
+// gets a specific unbonding queue timeslice. A timeslice is a slice of DVPairs
+// corresponding to unbonding delegations that expire at a certain time.
+func (k Keeper) GetUBDQueueTimeSlice(ctx sdk.Context, timestamp int64) (dvPairs []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetUnbondingDelegationTimeKey(timestamp))
+	if bz == nil {
+		return []types.DVPair{}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &dvPairs)
+	return dvPairs
+}
+
+// sets a specific unbonding queue timeslice
+func (k Keeper) SetUBDQueueTimeSlice(ctx sdk.Context, timestamp int64, keys []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(keys)
+	store.Set(GetUnbondingDelegationTimeKey(timestamp), bz)
+}
+
+// insert an unbonding delegation to the appropriate timeslice in the unbonding queue
+func (k Keeper) InsertUBDQueue(ctx sdk.Context, ubd types.UnbondingDelegation) {
+	timeSlice := k.GetUBDQueueTimeSlice(ctx, ubd.MinTime)
+	dvPair := types.DVPair{ubd.DelegatorAddr, ubd.ValidatorAddr}
+	k.SetUBDQueueTimeSlice(ctx, ubd.MinTime, append(timeSlice, dvPair))
+}
+
+// removes all the timeslices of the unbonding queue up to the given time,
+// returning the unbonding delegations which mature by then
+func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime int64) (matureUnbonds []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(UnbondingQueueKey, sdk.PrefixEndBytes(GetUnbondingDelegationTimeKey(currTime)))
+	for ; iterator.Valid(); iterator.Next() {
+		var timeslice []types.DVPair
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &timeslice)
+		matureUnbonds = append(matureUnbonds, timeslice...)
+		store.Delete(iterator.Key())
+	}
+	iterator.Close()
+	return matureUnbonds
+}
+
 //_____________________________________________________________________________________
 
 // load a redelegation
@@ -205,6 +246,47 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store.Delete(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
 }
 
+// gets a specific redelegation queue timeslice. A timeslice is a slice of
+// DVVTriplets corresponding to redelegations that expire at a certain time.
+func (k Keeper) GetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp int64) (dvvTriplets []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetRedelegationTimeKey(timestamp))
+	if bz == nil {
+		return []types.DVVTriplet{}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &dvvTriplets)
+	return dvvTriplets
+}
+
+// sets a specific redelegation queue timeslice
+func (k Keeper) SetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp int64, keys []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(keys)
+	store.Set(GetRedelegationTimeKey(timestamp), bz)
+}
+
+// insert a redelegation to the appropriate timeslice in the redelegation queue
+func (k Keeper) InsertRedelegationQueue(ctx sdk.Context, red types.Redelegation) {
+	timeSlice := k.GetRedelegationQueueTimeSlice(ctx, red.MinTime)
+	dvvTriplet := types.DVVTriplet{red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr}
+	k.SetRedelegationQueueTimeSlice(ctx, red.MinTime, append(timeSlice, dvvTriplet))
+}
+
+// removes all the timeslices of the redelegation queue up to the given time,
+// returning the redelegations which mature by then
+func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime int64) (matureRedelegations []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(RedelegationQueueKey, sdk.PrefixEndBytes(GetRedelegationTimeKey(currTime)))
+	for ; iterator.Valid(); iterator.Next() {
+		var timeslice []types.DVVTriplet
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &timeslice)
+		matureRedelegations = append(matureRedelegations, timeslice...)
+		store.Delete(iterator.Key())
+	}
+	iterator.Close()
+	return matureRedelegations
+}
+
 //_____________________________________________________________________________________
 
 // Perform a delegation, set/update everything necessary within the store
@@ -219,16 +301,21 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 			ValidatorAddr: validator.Owner,
 			Shares:        sdk.ZeroRat(),
 		}
+	} else if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delegatorAddr, validator.Owner)
 	}
 
 	// Account new shares, save
 	pool := k.GetPool(ctx)
-	_, _, err = k.coinKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
+	_, err = k.coinKeeper.DelegateCoinsFromAccountToModule(ctx, delegation.DelegatorAddr, types.ModuleName, sdk.Coins{bondAmt})
 	if err != nil {
 		return
 	}
 	validator, pool, newShares = validator.AddTokensFromDel(pool, bondAmt.Amount.Int64())
 	delegation.Shares = delegation.Shares.Add(newShares)
+	if bytes.Equal(delegatorAddr, validator.Owner) {
+		validator.SelfDelegationShares = validator.SelfDelegationShares.Add(newShares)
+	}
 
 	// Update delegation height
 	delegation.Height = ctx.BlockHeight()
@@ -236,6 +323,9 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 	k.SetPool(ctx, pool)
 	k.SetDelegation(ctx, delegation)
 	k.UpdateValidator(ctx, validator)
+	if k.hooks != nil {
+		k.hooks.AfterDelegationSharesModified(ctx, delegatorAddr, validator.Owner)
+	}
 
 	return
 }
@@ -264,17 +354,26 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address
 		return
 	}
 
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delegatorAddr, validatorAddr)
+	}
+
 	// subtract shares from delegator
 	delegation.Shares = delegation.Shares.Sub(shares)
+	pool := k.GetPool(ctx)
 
-	// remove the delegation
-	if delegation.Shares.IsZero() {
-
-		// if the delegation is the owner of the validator then
-		// trigger a revoke validator
-		if bytes.Equal(delegation.DelegatorAddr, validator.Owner) && validator.Revoked == false {
+	// if the delegation is the owner of the validator and its self-delegation
+	// is removed or falls below the minimum then trigger a revoke validator
+	if bytes.Equal(delegation.DelegatorAddr, validator.Owner) {
+		validator.SelfDelegationShares = validator.SelfDelegationShares.Sub(shares)
+		if validator.Revoked == false &&
+			(delegation.Shares.IsZero() || !validator.HasMinSelfDelegation(pool)) {
 			validator.Revoked = true
 		}
+	}
+
+	// remove the delegation
+	if delegation.Shares.IsZero() {
 		k.RemoveDelegation(ctx, delegation)
 	} else {
 		// Update height
@@ -283,13 +382,15 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address
 	}
 
 	// remove the coins from the validator
-	pool := k.GetPool(ctx)
 	validator, pool, amount = validator.RemoveDelShares(pool, shares)
 
 	k.SetPool(ctx, pool)
 
 	// update then remove validator if necessary
 	validator = k.UpdateValidator(ctx, validator)
+	if k.hooks != nil {
+		k.hooks.AfterDelegationSharesModified(ctx, delegatorAddr, validatorAddr)
+	}
 	if validator.DelegatorShares.IsZero() {
 		k.RemoveValidator(ctx, validator.Owner)
 	}
@@ -315,11 +416,13 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk
 	ubd := types.UnbondingDelegation{
 		DelegatorAddr:  delegatorAddr,
 		ValidatorAddr:  validatorAddr,
+		CreationHeight: ctx.BlockHeight(),
 		MinTime:        minTime,
 		Balance:        balance,
 		InitialBalance: balance,
 	}
 	k.SetUnbondingDelegation(ctx, ubd)
+	k.InsertUBDQueue(ctx, ubd)
 	return nil
 }
 
@@ -337,7 +440,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr
 		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
 	}
 
-	_, _, err := k.coinKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
+	_, err := k.coinKeeper.UndelegateCoinsFromModuleToAccount(ctx, types.ModuleName, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
 	if err != nil {
 		return err
 	}
@@ -377,6 +480,7 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAd
 		DelegatorAddr:    delegatorAddr,
 		ValidatorSrcAddr: validatorSrcAddr,
 		ValidatorDstAddr: validatorDstAddr,
+		CreationHeight:   ctx.BlockHeight(),
 		MinTime:          minTime,
 		SharesDst:        sharesCreated,
 		SharesSrc:        sharesAmount,
@@ -384,6 +488,7 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAd
 		InitialBalance:   returnCoin,
 	}
 	k.SetRedelegation(ctx, red)
+	k.InsertRedelegationQueue(ctx, red)
 	return nil
 }
 
@@ -138,6 +138,38 @@ func TestUnbondingDelegation(t *testing.T) {
 	require.False(t, found)
 }
 
+// tests the ordering of InsertUBDQueue, DequeueAllMatureUBDQueue
+func TestUnbondingQueueOrdering(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	// insert out of order, with two entries sharing a timeslice
+	minTimes := []int64{10, 5, 20, 5}
+	for i, minTime := range minTimes {
+		keeper.InsertUBDQueue(ctx, types.UnbondingDelegation{
+			DelegatorAddr: addrDels[i%2],
+			ValidatorAddr: addrVals[i],
+			MinTime:       minTime,
+		})
+	}
+
+	// nothing is mature before the earliest time
+	require.Equal(t, 0, len(keeper.DequeueAllMatureUBDQueue(ctx, 4)))
+
+	// mature entries are returned ordered by time, then by insertion
+	mature := keeper.DequeueAllMatureUBDQueue(ctx, 10)
+	require.Equal(t, []types.DVPair{
+		{addrDels[1], addrVals[1]},
+		{addrDels[1], addrVals[3]},
+		{addrDels[0], addrVals[0]},
+	}, mature)
+
+	// the dequeued timeslices are removed, the later one remains
+	require.Equal(t, 0, len(keeper.DequeueAllMatureUBDQueue(ctx, 10)))
+	require.Equal(t, 1, len(keeper.GetUBDQueueTimeSlice(ctx, 20)))
+	mature = keeper.DequeueAllMatureUBDQueue(ctx, 100)
+	require.Equal(t, []types.DVPair{{addrDels[0], addrVals[2]}}, mature)
+}
+
 func TestUnbondDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
@@ -20,11 +20,35 @@ func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
 
 	provisions := pool.Inflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat).RoundInt64()
 
-	// TODO add to the fees provisions
 	pool.LooseTokens += provisions
+	pool.UndistributedProvisions += provisions
+
+	// the provisions are newly minted tokens held by the module account
+	_, err := k.coinKeeper.MintModuleCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, provisions)})
+	if err != nil {
+		panic(err)
+	}
 	return pool
 }
 
+// move the inflation provisions not yet distributed to the module account of
+// the given name, returning them
+func (k Keeper) WithdrawProvisions(ctx sdk.Context, recipient string) sdk.Coins {
+	pool := k.GetPool(ctx)
+	if pool.UndistributedProvisions == 0 {
+		return sdk.Coins{}
+	}
+
+	provisions := sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, pool.UndistributedProvisions)}
+	_, err := k.coinKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, recipient, provisions)
+	if err != nil {
+		panic(err)
+	}
+	pool.UndistributedProvisions = 0
+	k.SetPool(ctx, pool)
+	return provisions
+}
+
 // get the next inflation rate for the hour
 func (k Keeper) NextInflation(ctx sdk.Context) (inflation sdk.Rat) {
 
@@ -5,6 +5,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -13,21 +14,33 @@ type Keeper struct {
 	storeKey   sdk.StoreKey
 	cdc        *wire.Codec
 	coinKeeper bank.Keeper
+	paramstore params.Subspace
+
+	// hooks called on validator and delegation changes, may be nil
+	hooks sdk.StakingHooks
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, paramstore params.Subspace, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
 		storeKey:   key,
 		cdc:        cdc,
 		coinKeeper: ck,
+		paramstore: paramstore.WithKeyTable(types.ParamKeyTable()),
 		codespace:  codespace,
 	}
 	return keeper
 }
 
+// WithHooks returns a copy of the keeper which calls the hooks on validator
+// and delegation changes
+func (k Keeper) WithHooks(hooks sdk.StakingHooks) Keeper {
+	k.hooks = hooks
+	return k
+}
+
 //_________________________________________________________________________
 
 // return the codespace
@@ -40,14 +53,7 @@ func (k Keeper) Codespace() sdk.CodespaceType {
 
 // load/save the global staking params
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-
-	b := store.Get(ParamKey)
-	if b == nil {
-		panic("Stored params should not have been nil")
-	}
-
-	k.cdc.MustUnmarshalBinary(b, &params)
+	k.paramstore.GetParamSet(ctx, &params)
 	return
 }
 
@@ -56,22 +62,27 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 // panic on retrieval if it doesn't exist - hence if we use setParams for the very
 // first params set it will panic.
 func (k Keeper) SetNewParams(ctx sdk.Context, params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	err := params.Validate()
+	if err != nil {
+		panic(err)
+	}
+	k.paramstore.SetParamSet(ctx, &params)
 }
 
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
-	store := ctx.KVStore(k.storeKey)
+	err := params.Validate()
+	if err != nil {
+		panic(err)
+	}
 	exParams := k.GetParams(ctx)
+	k.paramstore.SetParamSet(ctx, &params)
 
-	// if max validator count changes, must recalculate validator set
+	// if max validator count changes, must recalculate validator set with the
+	// new params
 	if exParams.MaxValidators != params.MaxValidators {
 		k.UpdateBondedValidatorsFull(ctx)
 	}
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
 }
 
 //_______________________________________________________________________
@@ -94,6 +105,25 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.Pool) {
 	store.Set(PoolKey, b)
 }
 
+// GetHeldTokens returns the tokens held by the stake module outside of accounts:
+// the tokens of all validators, the balances of unbonding delegations and the
+// provisions which haven't been distributed yet
+func (k Keeper) GetHeldTokens(ctx sdk.Context) sdk.Coins {
+	pool := k.GetPool(ctx)
+	held := pool.BondedTokens + pool.UnbondingTokens + pool.UnbondedTokens + pool.UndistributedProvisions
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, UnbondingDelegationKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var ubd types.UnbondingDelegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &ubd)
+		held += ubd.Balance.Amount.Int64()
+	}
+	iterator.Close()
+
+	return sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, held)}
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -21,6 +22,12 @@ func TestParams(t *testing.T) {
 	keeper.SetParams(ctx, expParams)
 	resParams = keeper.GetParams(ctx)
 	require.True(t, expParams.Equal(resParams))
+
+	// the inflation bounds are checked together
+	badParams := expParams
+	badParams.InflationMin = sdk.NewRat(30, 100)
+	require.Panics(t, func() { keeper.SetParams(ctx, badParams) })
+	require.True(t, expParams.Equal(keeper.GetParams(ctx)))
 }
 
 func TestPool(t *testing.T) {
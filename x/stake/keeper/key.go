@@ -15,7 +15,6 @@ import (
 //nolint
 var (
 	// Keys for store prefixes
-	ParamKey                         = []byte{0x00} // key for parameters relating to staking
 	PoolKey                          = []byte{0x01} // key for the staking pools
 	ValidatorsKey                    = []byte{0x02} // prefix for each key to a validator
 	ValidatorsByPubKeyIndexKey       = []byte{0x03} // prefix for each key to a validator index, by pubkey
@@ -31,6 +30,9 @@ var (
 	RedelegationKey                  = []byte{0x0D} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by validator owner
 	RedelegationByValDstIndexKey     = []byte{0x0F} // prefix for each key for an redelegation, by validator owner
+	UnbondingQueueKey                = []byte{0x10} // prefix for the timestamps in the unbonding queue
+	RedelegationQueueKey             = []byte{0x11} // prefix for the timestamps in the redelegation queue
+	LastValidatorPowerKey            = []byte{0x12} // prefix for each key to the power of a validator last reported to tendermint
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -89,6 +91,11 @@ func GetTendermintUpdatesKey(ownerAddr sdk.Address) []byte {
 	return append(TendermintUpdatesKey, ownerAddr.Bytes()...)
 }
 
+// get the key for the power of a validator last reported to tendermint
+func GetLastValidatorPowerKey(ownerAddr sdk.Address) []byte {
+	return append(LastValidatorPowerKey, ownerAddr.Bytes()...)
+}
+
 //________________________________________________________________________________
 
 // get the key for delegator bond with validator
@@ -128,6 +135,11 @@ func GetUBDsByValIndexKey(validatorAddr sdk.Address, cdc *wire.Codec) []byte {
 	return append(UnbondingDelegationByValIndexKey, res...)
 }
 
+// get the key for the unbonding delegations maturing at a unix time
+func GetUnbondingDelegationTimeKey(timestamp int64) []byte {
+	return append(UnbondingQueueKey, getTimeBytes(timestamp)...)
+}
+
 //________________________________________________________________________________
 
 // get the key for a redelegation
@@ -168,6 +180,11 @@ func GetREDByValDstIndexKey(delegatorAddr, validatorSrcAddr,
 
 //______________
 
+// get the key for the redelegations maturing at a unix time
+func GetRedelegationTimeKey(timestamp int64) []byte {
+	return append(RedelegationQueueKey, getTimeBytes(timestamp)...)
+}
+
 // get the prefix keyspace for redelegations from a delegator
 func GetREDsKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
 	res := cdc.MustMarshalBinary(&delegatorAddr)
@@ -195,3 +212,12 @@ func GetREDsByDelToValDstIndexKey(delegatorAddr sdk.Address,
 		GetREDsToValDstIndexKey(validatorDstAddr, cdc),
 		delegatorAddr.Bytes()...)
 }
+
+//________________________________________________________________________________
+
+// big-endian time, so that the queues are iterated in time order
+func getTimeBytes(timestamp int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(timestamp))
+	return bz
+}
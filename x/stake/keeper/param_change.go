@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// CheckParamChange checks a change of a stake param proposed to governance,
+// the bond denom can't be changed as the bonded tokens are held in it
+func (k Keeper) CheckParamChange(key string, value string) sdk.Error {
+	if key == string(types.KeyBondDenom) {
+		return params.ErrInvalidParamValue(params.DefaultCodespace, types.DefaultParamspace, key, "cannot be changed by governance")
+	}
+	return k.paramstore.CheckParamChange(key, value)
+}
+
+// ApplyParamChange applies a change of a stake param through SetParams, so
+// that the bonded validators are updated with the new params
+func (k Keeper) ApplyParamChange(ctx sdk.Context, key string, value string) sdk.Error {
+	err := k.CheckParamChange(key, value)
+	if err != nil {
+		return err
+	}
+
+	// decode the change into the current params, without writing it
+	cacheCtx, _ := ctx.CacheContext()
+	err = k.paramstore.ApplyParamChange(cacheCtx, key, value)
+	if err != nil {
+		return err
+	}
+	newParams := k.GetParams(cacheCtx)
+	if err := newParams.Validate(); err != nil {
+		return params.ErrInvalidParamValue(params.DefaultCodespace, types.DefaultParamspace, key, err.Error())
+	}
+
+	k.SetParams(ctx, newParams)
+	return nil
+}
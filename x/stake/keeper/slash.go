@@ -91,6 +91,7 @@ func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight in
 	pool.LooseTokens -= burned
 	// update the pool
 	k.SetPool(ctx, pool)
+	k.burnTokens(ctx, burned)
 	// update the validator, possibly kicking it out
 	k.UpdateValidator(ctx, validator)
 
@@ -119,12 +120,16 @@ func (k Keeper) Unrevoke(ctx sdk.Context, pubkey crypto.PubKey) {
 	return
 }
 
-// set the revoked flag on a validator
+// set the revoked flag on a validator, a validator without
+// the minimum self-delegation stays revoked
 func (k Keeper) setRevoked(ctx sdk.Context, pubkey crypto.PubKey, revoked bool) {
 	validator, found := k.GetValidatorByPubKey(ctx, pubkey)
 	if !found {
 		panic(fmt.Errorf("Validator with pubkey %s not found, cannot set revoked to %v", pubkey, revoked))
 	}
+	if !revoked && !validator.HasMinSelfDelegation(k.GetPool(ctx)) {
+		return
+	}
 	validator.Revoked = revoked
 	k.UpdateValidator(ctx, validator) // update validator, possibly unbonding or bonding it
 	return
@@ -168,8 +173,9 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 		pool := k.GetPool(ctx)
 		// Burn loose tokens
 		// Ref https://github.com/cosmos/cosmos-sdk/pull/1278#discussion_r198657760
-		pool.LooseTokens -= slashAmount.Int64()
+		pool.LooseTokens -= unbondingSlashAmount.Int64()
 		k.SetPool(ctx, pool)
+		k.burnTokens(ctx, unbondingSlashAmount.Int64())
 	}
 
 	return
@@ -231,7 +237,19 @@ func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, re
 		pool := k.GetPool(ctx)
 		pool.LooseTokens -= tokensToBurn
 		k.SetPool(ctx, pool)
+		k.burnTokens(ctx, tokensToBurn)
 	}
 
 	return slashAmount
 }
+
+// burn slashed tokens from the module account
+func (k Keeper) burnTokens(ctx sdk.Context, amount int64) {
+	if amount == 0 {
+		return
+	}
+	_, err := k.coinKeeper.BurnModuleCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, amount)})
+	if err != nil {
+		panic(err)
+	}
+}
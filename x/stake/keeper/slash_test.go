@@ -27,11 +27,18 @@ func setupHelper(t *testing.T, amt int64) (sdk.Context, Keeper, types.Params) {
 		keeper.SetPool(ctx, pool)
 		keeper.UpdateValidator(ctx, validator)
 		keeper.SetValidatorByPubKeyIndex(ctx, validator)
+		fundModuleAccount(t, ctx, keeper, amt)
 	}
 
 	return ctx, keeper, params
 }
 
+// mint the tokens of state set up directly into the module account so they can be burned
+func fundModuleAccount(t *testing.T, ctx sdk.Context, keeper Keeper, amt int64) {
+	_, err := keeper.coinKeeper.MintModuleCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewCoin(keeper.GetParams(ctx).BondDenom, amt)})
+	require.Nil(t, err)
+}
+
 // tests Revoke, Unrevoke
 func TestRevocation(t *testing.T) {
 	// setup
@@ -99,6 +106,16 @@ func TestSlashUnbondingDelegation(t *testing.T) {
 	require.Equal(t, sdk.NewCoin(params.BondDenom, 5), ubd.Balance)
 	newPool := keeper.GetPool(ctx)
 	require.Equal(t, int64(5), oldPool.LooseTokens-newPool.LooseTokens)
+
+	// a second slash can't burn more than the remaining balance
+	oldPool = newPool
+	slashAmount = keeper.slashUnbondingDelegation(ctx, ubd, 0, sdk.OneRat())
+	require.Equal(t, int64(10), slashAmount.Int64())
+	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 0), ubd.Balance)
+	newPool = keeper.GetPool(ctx)
+	require.Equal(t, int64(5), oldPool.LooseTokens-newPool.LooseTokens)
 }
 
 // tests slashRedelegation
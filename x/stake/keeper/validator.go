@@ -85,6 +85,17 @@ func (k Keeper) GetAllValidators(ctx sdk.Context) (validators []types.Validator)
 	return validators
 }
 
+// Reset the commission change of the day of all validators
+func (k Keeper) ResetCommissionChangesToday(ctx sdk.Context) {
+	for _, validator := range k.GetAllValidators(ctx) {
+		if validator.CommissionChangeToday.IsZero() {
+			continue
+		}
+		validator.CommissionChangeToday = sdk.ZeroRat()
+		k.SetValidator(ctx, validator)
+	}
+}
+
 // Get the set of all validators, retrieve a maxRetrieve number of records
 func (k Keeper) GetValidators(ctx sdk.Context, maxRetrieve int16) (validators []types.Validator) {
 	store := ctx.KVStore(k.storeKey)
@@ -166,31 +177,129 @@ func (k Keeper) GetValidatorsByPower(ctx sdk.Context) []types.Validator {
 //_________________________________________________________________________
 // Accumulated updates to the active/bonded validator set for tendermint
 
-// get the most recently updated validators
+// get the most recently updated validators, with the total power change
+// capped by the MaxPowerChange param
 func (k Keeper) GetTendermintUpdates(ctx sdk.Context) (updates []abci.Validator) {
+	for _, update := range k.cappedTendermintUpdates(ctx) {
+		if update.unknown {
+			continue
+		}
+		updates = append(updates, update.validator)
+	}
+	return
+}
+
+// remove the validator update entries after applied to Tendermint, the
+// updates deferred by the power change cap are kept for the next blocks
+func (k Keeper) ClearTendermintUpdates(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	for _, update := range k.cappedTendermintUpdates(ctx) {
+		k.setLastValidatorPower(ctx, update.owner, update.validator.Power)
+		if update.complete {
+			store.Delete(GetTendermintUpdatesKey(update.owner))
+		}
+	}
+}
+
+// a validator update reported to tendermint, which is not complete when
+// only part of the power change could be applied. The removal of a validator
+// tendermint never had is unknown to it and only cleared, never reported.
+type tendermintUpdate struct {
+	owner     sdk.Address
+	validator abci.Validator
+	complete  bool
+	unknown   bool
+}
+
+// apply the accumulated validator updates in owner address order until the
+// maximum power change of the block is reached, moving the power of the
+// validator at the cap part of the way and deferring the remaining updates
+func (k Keeper) cappedTendermintUpdates(ctx sdk.Context) (updates []tendermintUpdate) {
 	store := ctx.KVStore(k.storeKey)
 
+	// no limit if the cap is disabled or no power was reported yet
+	maxChange := int64(-1)
+	maxPowerChange := k.GetParams(ctx).MaxPowerChange
+	totalPower := k.GetLastTotalPower(ctx)
+	if !maxPowerChange.IsZero() && totalPower > 0 {
+		maxChange = maxPowerChange.Num().MulRaw(totalPower).Div(maxPowerChange.Denom()).Int64()
+		if maxChange < 1 {
+			maxChange = 1
+		}
+	}
+
 	iterator := sdk.KVStorePrefixIterator(store, TendermintUpdatesKey) //smallest to largest
 	for ; iterator.Valid(); iterator.Next() {
-		valBytes := iterator.Value()
 		var val abci.Validator
-		k.cdc.MustUnmarshalBinary(valBytes, &val)
-		updates = append(updates, val)
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &val)
+		owner := sdk.Address(iterator.Key()[len(TendermintUpdatesKey):])
+
+		lastPower := k.GetLastValidatorPower(ctx, owner)
+		if lastPower == 0 && val.Power == 0 {
+			updates = append(updates, tendermintUpdate{owner, val, true, true})
+			continue
+		}
+		change := val.Power - lastPower
+		if change < 0 {
+			change = -change
+		}
+		if maxChange < 0 || change <= maxChange {
+			updates = append(updates, tendermintUpdate{owner, val, true, false})
+			if maxChange > 0 {
+				maxChange -= change
+			}
+			continue
+		}
+
+		if maxChange == 0 {
+			continue
+		}
+
+		// only move part of the way towards the new power
+		if val.Power > lastPower {
+			val.Power = lastPower + maxChange
+		} else {
+			val.Power = lastPower - maxChange
+		}
+		updates = append(updates, tendermintUpdate{owner, val, false, false})
+		maxChange = 0
 	}
 	iterator.Close()
 	return
 }
 
-// remove all validator update entries after applied to Tendermint
-func (k Keeper) ClearTendermintUpdates(ctx sdk.Context) {
+// get the power of a validator last reported to tendermint
+func (k Keeper) GetLastValidatorPower(ctx sdk.Context, ownerAddr sdk.Address) (power int64) {
 	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetLastValidatorPowerKey(ownerAddr))
+	if bz == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinary(bz, &power)
+	return
+}
+
+// set the power of a validator last reported to tendermint
+func (k Keeper) setLastValidatorPower(ctx sdk.Context, ownerAddr sdk.Address, power int64) {
+	store := ctx.KVStore(k.storeKey)
+	if power == 0 {
+		store.Delete(GetLastValidatorPowerKey(ownerAddr))
+		return
+	}
+	store.Set(GetLastValidatorPowerKey(ownerAddr), k.cdc.MustMarshalBinary(power))
+}
 
-	// delete subspace
-	iterator := sdk.KVStorePrefixIterator(store, TendermintUpdatesKey)
+// get the total power last reported to tendermint
+func (k Keeper) GetLastTotalPower(ctx sdk.Context) (total int64) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, LastValidatorPowerKey)
 	for ; iterator.Valid(); iterator.Next() {
-		store.Delete(iterator.Key())
+		var power int64
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &power)
+		total += power
 	}
 	iterator.Close()
+	return
 }
 
 //___________________________________________________________________________
@@ -488,6 +597,9 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.Address) {
 	if !found {
 		return
 	}
+	if k.hooks != nil {
+		k.hooks.BeforeValidatorRemoved(ctx, address)
+	}
 
 	// delete the old validator record
 	store := ctx.KVStore(k.storeKey)
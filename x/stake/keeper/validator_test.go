@@ -410,6 +410,29 @@ func TestValidatorBondHeight(t *testing.T) {
 	assert.True(ValEq(t, validators[2], resValidators[1]))
 }
 
+func TestSetParamsMaxValidators(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+
+	amts := []int64{100, 200, 300}
+	for i, amt := range amts {
+		pool := keeper.GetPool(ctx)
+		validator := types.NewValidator(Addrs[i], PKs[i], types.Description{})
+		validator, pool, _ = validator.AddTokensFromDel(pool, amt)
+		keeper.SetPool(ctx, pool)
+		keeper.UpdateValidator(ctx, validator)
+	}
+	require.Equal(t, 3, len(keeper.GetValidatorsBonded(ctx)))
+
+	// the bonded set is recomputed with the new maximum
+	params := keeper.GetParams(ctx)
+	params.MaxValidators = 2
+	keeper.SetParams(ctx, params)
+	require.Equal(t, 2, len(keeper.GetValidatorsBonded(ctx)))
+	validator, found := keeper.GetValidator(ctx, Addrs[0])
+	require.True(t, found)
+	require.Equal(t, sdk.Unbonded, validator.Status())
+}
+
 func TestFullValidatorSetPowerChange(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 1000)
 	params := keeper.GetParams(ctx)
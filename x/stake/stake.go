@@ -20,6 +20,7 @@ type UnbondingDelegation = types.UnbondingDelegation
 type Redelegation = types.Redelegation
 type Params = types.Params
 type Pool = types.Pool
+type SelfBond = types.SelfBond
 type PoolShares = types.PoolShares
 type MsgCreateValidator = types.MsgCreateValidator
 type MsgEditValidator = types.MsgEditValidator
@@ -38,7 +39,6 @@ var (
 	GetTendermintUpdatesKey      = keeper.GetTendermintUpdatesKey
 	GetDelegationKey             = keeper.GetDelegationKey
 	GetDelegationsKey            = keeper.GetDelegationsKey
-	ParamKey                     = keeper.ParamKey
 	PoolKey                      = keeper.PoolKey
 	ValidatorsKey                = keeper.ValidatorsKey
 	ValidatorsByPubKeyIndexKey   = keeper.ValidatorsByPubKeyIndexKey
@@ -62,6 +62,7 @@ var (
 	GetREDsByDelToValDstIndexKey = keeper.GetREDsByDelToValDstIndexKey
 
 	DefaultParams       = types.DefaultParams
+	ParamKeyTable       = types.ParamKeyTable
 	InitialPool         = types.InitialPool
 	NewUnbondedShares   = types.NewUnbondedShares
 	NewUnbondingShares  = types.NewUnbondingShares
@@ -73,15 +74,21 @@ var (
 	RegisterWire        = types.RegisterWire
 
 	// messages
-	NewMsgCreateValidator    = types.NewMsgCreateValidator
-	NewMsgEditValidator      = types.NewMsgEditValidator
-	NewMsgDelegate           = types.NewMsgDelegate
-	NewMsgBeginUnbonding     = types.NewMsgBeginUnbonding
-	NewMsgCompleteUnbonding  = types.NewMsgCompleteUnbonding
-	NewMsgBeginRedelegate    = types.NewMsgBeginRedelegate
-	NewMsgCompleteRedelegate = types.NewMsgCompleteRedelegate
+	NewMsgCreateValidator               = types.NewMsgCreateValidator
+	NewMsgCreateValidatorWithCommission = types.NewMsgCreateValidatorWithCommission
+	NewMsgEditValidator                 = types.NewMsgEditValidator
+	NewMsgDelegate                      = types.NewMsgDelegate
+	NewMsgBeginUnbonding                = types.NewMsgBeginUnbonding
+	NewMsgCompleteUnbonding             = types.NewMsgCompleteUnbonding
+	NewMsgBeginRedelegate               = types.NewMsgBeginRedelegate
+	NewMsgCompleteRedelegate            = types.NewMsgCompleteRedelegate
 )
 
+const ModuleName = types.ModuleName
+
+// default paramspace for the stake params
+const DefaultParamspace = types.DefaultParamspace
+
 // errors
 const (
 	DefaultCodespace      = types.DefaultCodespace
@@ -95,14 +102,20 @@ const (
 )
 
 var (
-	ErrNilValidatorAddr       = types.ErrNilValidatorAddr
-	ErrNoValidatorFound       = types.ErrNoValidatorFound
-	ErrValidatorAlreadyExists = types.ErrValidatorAlreadyExists
-	ErrValidatorRevoked       = types.ErrValidatorRevoked
-	ErrBadRemoveValidator     = types.ErrBadRemoveValidator
-	ErrDescriptionLength      = types.ErrDescriptionLength
-	ErrCommissionNegative     = types.ErrCommissionNegative
-	ErrCommissionHuge         = types.ErrCommissionHuge
+	ErrNilValidatorAddr               = types.ErrNilValidatorAddr
+	ErrNoValidatorFound               = types.ErrNoValidatorFound
+	ErrValidatorAlreadyExists         = types.ErrValidatorAlreadyExists
+	ErrValidatorRevoked               = types.ErrValidatorRevoked
+	ErrBadRemoveValidator             = types.ErrBadRemoveValidator
+	ErrDescriptionLength              = types.ErrDescriptionLength
+	ErrCommissionNegative             = types.ErrCommissionNegative
+	ErrCommissionHuge                 = types.ErrCommissionHuge
+	ErrCommissionMissing              = types.ErrCommissionMissing
+	ErrCommissionExceedsMax           = types.ErrCommissionExceedsMax
+	ErrCommissionChangeRateExceedsMax = types.ErrCommissionChangeRateExceedsMax
+	ErrCommissionChangeTooBig         = types.ErrCommissionChangeTooBig
+	ErrMinSelfDelegationInvalid       = types.ErrMinSelfDelegationInvalid
+	ErrSelfDelegationBelowMinimum     = types.ErrSelfDelegationBelowMinimum
 
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
@@ -141,3 +141,18 @@ func (d Redelegation) HumanReadableString() (string, error) {
 	return resp, nil
 
 }
+
+//__________________________________________________________________
+
+// DVPair - addresses of an unbonding delegation, as kept in the unbonding queue
+type DVPair struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+}
+
+// DVVTriplet - addresses of a redelegation, as kept in the redelegation queue
+type DVVTriplet struct {
+	DelegatorAddr    sdk.Address `json:"delegator_addr"`
+	ValidatorSrcAddr sdk.Address `json:"validator_src_addr"`
+	ValidatorDstAddr sdk.Address `json:"validator_dst_addr"`
+}
@@ -47,6 +47,26 @@ func ErrCommissionNegative(codespace sdk.CodespaceType) sdk.Error {
 func ErrCommissionHuge(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be more than 100%")
 }
+func ErrCommissionMissing(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission rates must be included")
+}
+func ErrCommissionExceedsMax(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be more than the max rate")
+}
+func ErrCommissionChangeRateExceedsMax(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission change rate cannot be more than the max rate")
+}
+func ErrCommissionChangeTooBig(codespace sdk.CodespaceType, changeToday, changeRate sdk.Rat) sdk.Error {
+	msg := fmt.Sprintf("commission cannot change by more than %v a day, already changed by %v today",
+		changeRate.FloatString(), changeToday.FloatString())
+	return sdk.NewError(codespace, CodeInvalidValidator, msg)
+}
+func ErrMinSelfDelegationInvalid(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "minimum self-delegation must be positive")
+}
+func ErrSelfDelegationBelowMinimum(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "self-delegation cannot be less than the minimum self-delegation")
+}
 
 // delegation
 func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
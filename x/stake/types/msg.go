@@ -27,18 +27,34 @@ var maximumBondingRationalDenominator sdk.Int = sdk.NewInt(int64(math.Pow10(MaxB
 // MsgCreateValidator - struct for unbonding transactions
 type MsgCreateValidator struct {
 	Description
-	ValidatorAddr  sdk.Address   `json:"address"`
-	PubKey         crypto.PubKey `json:"pubkey"`
-	SelfDelegation sdk.Coin      `json:"self_delegation"`
+	ValidatorAddr        sdk.Address   `json:"address"`
+	PubKey               crypto.PubKey `json:"pubkey"`
+	SelfDelegation       sdk.Coin      `json:"self_delegation"`
+	Commission           sdk.Rat       `json:"commission"`
+	CommissionMax        sdk.Rat       `json:"commission_max"`
+	CommissionChangeRate sdk.Rat       `json:"commission_change_rate"`
+	MinSelfDelegation    sdk.Int       `json:"min_self_delegation"`
 }
 
+// create a validator charging no commission, which must keep at least one token self-delegated
 func NewMsgCreateValidator(validatorAddr sdk.Address, pubkey crypto.PubKey,
 	selfDelegation sdk.Coin, description Description) MsgCreateValidator {
+	return NewMsgCreateValidatorWithCommission(validatorAddr, pubkey, selfDelegation, description,
+		sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat(), sdk.OneInt())
+}
+
+func NewMsgCreateValidatorWithCommission(validatorAddr sdk.Address, pubkey crypto.PubKey,
+	selfDelegation sdk.Coin, description Description,
+	commission, commissionMax, commissionChangeRate sdk.Rat, minSelfDelegation sdk.Int) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:    description,
-		ValidatorAddr:  validatorAddr,
-		PubKey:         pubkey,
-		SelfDelegation: selfDelegation,
+		Description:          description,
+		ValidatorAddr:        validatorAddr,
+		PubKey:               pubkey,
+		SelfDelegation:       selfDelegation,
+		Commission:           commission,
+		CommissionMax:        commissionMax,
+		CommissionChangeRate: commissionChangeRate,
+		MinSelfDelegation:    minSelfDelegation,
 	}
 }
 
@@ -52,13 +68,21 @@ func (msg MsgCreateValidator) GetSigners() []sdk.Address {
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string   `json:"address"`
-		PubKey        string   `json:"pubkey"`
-		Bond          sdk.Coin `json:"bond"`
+		ValidatorAddr        string   `json:"address"`
+		PubKey               string   `json:"pubkey"`
+		Bond                 sdk.Coin `json:"bond"`
+		Commission           sdk.Rat  `json:"commission"`
+		CommissionMax        sdk.Rat  `json:"commission_max"`
+		CommissionChangeRate sdk.Rat  `json:"commission_change_rate"`
+		MinSelfDelegation    sdk.Int  `json:"min_self_delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
-		PubKey:        sdk.MustBech32ifyValPub(msg.PubKey),
+		Description:          msg.Description,
+		ValidatorAddr:        sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		PubKey:               sdk.MustBech32ifyValPub(msg.PubKey),
+		Commission:           msg.Commission,
+		CommissionMax:        msg.CommissionMax,
+		CommissionChangeRate: msg.CommissionChangeRate,
+		MinSelfDelegation:    msg.MinSelfDelegation,
 	})
 	if err != nil {
 		panic(err)
@@ -74,11 +98,17 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if !(msg.SelfDelegation.Amount.GT(sdk.ZeroInt())) {
 		return ErrBadDelegationAmount(DefaultCodespace)
 	}
+	if !(msg.MinSelfDelegation.GT(sdk.ZeroInt())) {
+		return ErrMinSelfDelegationInvalid(DefaultCodespace)
+	}
+	if msg.SelfDelegation.Amount.LT(msg.MinSelfDelegation) {
+		return ErrSelfDelegationBelowMinimum(DefaultCodespace)
+	}
 	empty := Description{}
 	if msg.Description == empty {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
-	return nil
+	return ValidateCommission(msg.Commission, msg.CommissionMax, msg.CommissionChangeRate)
 }
 
 //______________________________________________________________________
@@ -87,12 +117,14 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 type MsgEditValidator struct {
 	Description
 	ValidatorAddr sdk.Address `json:"address"`
+	Commission    *sdk.Rat    `json:"commission"` // new commission rate, nil to leave it unchanged
 }
 
-func NewMsgEditValidator(validatorAddr sdk.Address, description Description) MsgEditValidator {
+func NewMsgEditValidator(validatorAddr sdk.Address, description Description, commission *sdk.Rat) MsgEditValidator {
 	return MsgEditValidator{
 		Description:   description,
 		ValidatorAddr: validatorAddr,
+		Commission:    commission,
 	}
 }
 
@@ -106,10 +138,12 @@ func (msg MsgEditValidator) GetSigners() []sdk.Address {
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string `json:"address"`
+		ValidatorAddr string   `json:"address"`
+		Commission    *sdk.Rat `json:"commission,omitempty"`
 	}{
 		Description:   msg.Description,
 		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		Commission:    msg.Commission,
 	})
 	if err != nil {
 		panic(err)
@@ -123,9 +157,20 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 	empty := Description{}
-	if msg.Description == empty {
+	if msg.Description == empty && msg.Commission == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+	if msg.Commission != nil {
+		if msg.Commission.Rat == nil {
+			return ErrCommissionMissing(DefaultCodespace)
+		}
+		if msg.Commission.LT(sdk.ZeroRat()) {
+			return ErrCommissionNegative(DefaultCodespace)
+		}
+		if msg.Commission.GT(sdk.OneRat()) {
+			return ErrCommissionHuge(DefaultCodespace)
+		}
+	}
 	return nil
 }
 
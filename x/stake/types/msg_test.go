@@ -45,6 +45,60 @@ func TestMsgCreateValidator(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for the commission rates of MsgCreateValidator
+func TestMsgCreateValidatorCommission(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		commission, commissionMax, changeRate sdk.Rat
+		expectPass                            bool
+	}{
+		{"basic good", sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), true},
+		{"no commission", sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat(), true},
+		{"missing commission", sdk.Rat{}, sdk.ZeroRat(), sdk.ZeroRat(), false},
+		{"negative commission", sdk.NewRat(-1, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), false},
+		{"max above one", sdk.NewRat(1, 10), sdk.NewRat(11, 10), sdk.NewRat(1, 100), false},
+		{"commission above max", sdk.NewRat(3, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), false},
+		{"change rate above max", sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(3, 10), false},
+	}
+
+	for _, tc := range tests {
+		description := NewDescription("a", "b", "c", "d")
+		msg := NewMsgCreateValidatorWithCommission(addr1, pk1, coinPos, description,
+			tc.commission, tc.commissionMax, tc.changeRate, sdk.OneInt())
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+// test ValidateBasic for the minimum self-delegation of MsgCreateValidator
+func TestMsgCreateValidatorMinSelfDelegation(t *testing.T) {
+	tests := []struct {
+		name              string
+		minSelfDelegation sdk.Int
+		expectPass        bool
+	}{
+		{"basic good", sdk.NewInt(10), true},
+		{"equal to bond", sdk.NewInt(1000), true},
+		{"above bond", sdk.NewInt(1001), false},
+		{"zero minimum", sdk.ZeroInt(), false},
+		{"negative minimum", sdk.NewInt(-1), false},
+	}
+
+	for _, tc := range tests {
+		description := NewDescription("a", "b", "c", "d")
+		msg := NewMsgCreateValidatorWithCommission(addr1, pk1, coinPos, description,
+			sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat(), tc.minSelfDelegation)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 // test ValidateBasic for MsgEditValidator
 func TestMsgEditValidator(t *testing.T) {
 	tests := []struct {
@@ -60,13 +114,21 @@ func TestMsgEditValidator(t *testing.T) {
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgEditValidator(tc.validatorAddr, description)
+		msg := NewMsgEditValidator(tc.validatorAddr, description, nil)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
 			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		}
 	}
+
+	// the commission can be edited alone
+	commission := sdk.NewRat(1, 10)
+	require.Nil(t, NewMsgEditValidator(addr1, Description{}, &commission).ValidateBasic())
+	commission = sdk.NewRat(-1, 10)
+	require.NotNil(t, NewMsgEditValidator(addr1, Description{}, &commission).ValidateBasic())
+	commission = sdk.NewRat(11, 10)
+	require.NotNil(t, NewMsgEditValidator(addr1, Description{}, &commission).ValidateBasic())
 }
 
 // test ValidateBasic for MsgDelegate
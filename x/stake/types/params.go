@@ -2,8 +2,25 @@ package types
 
 import (
 	"bytes"
+	"errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// default paramspace for the stake params
+const DefaultParamspace = "stake"
+
+// keys of the params in the paramspace
+var (
+	KeyInflationRateChange = []byte("InflationRateChange")
+	KeyInflationMax        = []byte("InflationMax")
+	KeyInflationMin        = []byte("InflationMin")
+	KeyGoalBonded          = []byte("GoalBonded")
+	KeyUnbondingTime       = []byte("UnbondingTime")
+	KeyMaxValidators       = []byte("MaxValidators")
+	KeyBondDenom           = []byte("BondDenom")
+	KeyMaxPowerChange      = []byte("MaxPowerChange")
 )
 
 // Params defines the high level settings for staking
@@ -17,6 +34,65 @@ type Params struct {
 
 	MaxValidators uint16 `json:"max_validators"` // maximum number of validators
 	BondDenom     string `json:"bond_denom"`     // bondable coin denomination
+
+	MaxPowerChange sdk.Rat `json:"max_power_change"` // maximum fraction of the voting power changed in a block, zero for no limit
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyInflationRateChange, &p.InflationRateChange, validateFraction},
+		{KeyInflationMax, &p.InflationMax, validateFraction},
+		{KeyInflationMin, &p.InflationMin, validateFraction},
+		{KeyGoalBonded, &p.GoalBonded, validateFraction},
+		{KeyUnbondingTime, &p.UnbondingTime, validateUnbondingTime},
+		{KeyMaxValidators, &p.MaxValidators, validateMaxValidators},
+		{KeyBondDenom, &p.BondDenom, validateBondDenom},
+		{KeyMaxPowerChange, &p.MaxPowerChange, validateFraction},
+	}
+}
+
+// ParamKeyTable declares the stake params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func validateFraction(value interface{}) error {
+	rat := value.(sdk.Rat)
+	if rat.LT(sdk.ZeroRat()) || rat.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateUnbondingTime(value interface{}) error {
+	if value.(int64) < 0 {
+		return errors.New("cannot be negative")
+	}
+	return nil
+}
+
+func validateMaxValidators(value interface{}) error {
+	if value.(uint16) == 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func validateBondDenom(value interface{}) error {
+	if len(value.(string)) == 0 {
+		return errors.New("cannot be empty")
+	}
+	return nil
+}
+
+// Validate checks the constraints between the params, each param is checked
+// on its own as it is set
+func (p Params) Validate() error {
+	if p.InflationMin.GT(p.InflationMax) {
+		return errors.New("inflation min cannot be greater than inflation max")
+	}
+	return nil
 }
 
 // nolint
@@ -36,5 +112,6 @@ func DefaultParams() Params {
 		UnbondingTime:       60 * 60 * 24 * 3, // 3 weeks in seconds
 		MaxValidators:       100,
 		BondDenom:           "steak",
+		MaxPowerChange:      sdk.ZeroRat(),
 	}
 }
@@ -7,6 +7,9 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// ModuleName is the name of the module account escrowing the staked tokens
+const ModuleName = "stake"
+
 // Pool - dynamic parameters of the current state
 type Pool struct {
 	LooseTokens       int64   `json:"loose_tokens"`        // tokens not associated with any validator
@@ -23,6 +26,8 @@ type Pool struct {
 
 	// Fee Related
 	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // last recorded bonded shares - for fee calculations
+
+	UndistributedProvisions int64 `json:"undistributed_provisions"` // inflation provisions not yet distributed to any account
 }
 
 // nolint
@@ -46,6 +51,7 @@ func InitialPool() Pool {
 		Inflation:               sdk.NewRat(7, 100),
 		DateLastCommissionReset: 0,
 		PrevBondedShares:        sdk.ZeroRat(),
+		UndistributedProvisions: 0,
 	}
 }
 
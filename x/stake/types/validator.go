@@ -26,15 +26,18 @@ type Validator struct {
 	PoolShares      PoolShares `json:"pool_shares"`      // total shares for tokens held in the pool
 	DelegatorShares sdk.Rat    `json:"delegator_shares"` // total shares issued to a validator's delegators
 
+	SelfDelegationShares sdk.Rat `json:"self_delegation_shares"` // delegator shares held by the validator owner
+	MinSelfDelegation    sdk.Int `json:"min_self_delegation"`    // minimum tokens the owner must keep delegated to stay unrevoked
+
 	Description        Description `json:"description"`           // description terms for the validator
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission            sdk.Rat `json:"commission"`              // the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Rat `json:"commission_max"`          // maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // maximum daily change of the validator commission
+	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // commission rate change today, reset each day
 
 	// fee related
 	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
@@ -48,6 +51,8 @@ func NewValidator(owner sdk.Address, pubKey crypto.PubKey, description Descripti
 		Revoked:               false,
 		PoolShares:            NewUnbondedShares(sdk.ZeroRat()),
 		DelegatorShares:       sdk.ZeroRat(),
+		SelfDelegationShares:  sdk.ZeroRat(),
+		MinSelfDelegation:     sdk.ZeroInt(),
 		Description:           description,
 		BondHeight:            int64(0),
 		BondIntraTxCounter:    int16(0),
@@ -66,6 +71,8 @@ func (v Validator) Equal(c2 Validator) bool {
 		bytes.Equal(v.Owner, c2.Owner) &&
 		v.PoolShares.Equal(c2.PoolShares) &&
 		v.DelegatorShares.Equal(c2.DelegatorShares) &&
+		v.SelfDelegationShares.Equal(c2.SelfDelegationShares) &&
+		v.MinSelfDelegation.Equal(c2.MinSelfDelegation) &&
 		v.Description == c2.Description &&
 		//v.BondHeight == c2.BondHeight &&
 		//v.BondIntraTxCounter == c2.BondIntraTxCounter && // counter is always changing
@@ -133,6 +140,50 @@ func (d Description) EnsureLength() (Description, sdk.Error) {
 	return d, nil
 }
 
+// ensure the commission rates a validator is created with are consistent
+func ValidateCommission(commission, commissionMax, commissionChangeRate sdk.Rat) sdk.Error {
+	if commission.Rat == nil || commissionMax.Rat == nil || commissionChangeRate.Rat == nil {
+		return ErrCommissionMissing(DefaultCodespace)
+	}
+	if commission.LT(sdk.ZeroRat()) || commissionMax.LT(sdk.ZeroRat()) || commissionChangeRate.LT(sdk.ZeroRat()) {
+		return ErrCommissionNegative(DefaultCodespace)
+	}
+	if commissionMax.GT(sdk.OneRat()) {
+		return ErrCommissionHuge(DefaultCodespace)
+	}
+	if commission.GT(commissionMax) {
+		return ErrCommissionExceedsMax(DefaultCodespace)
+	}
+	if commissionChangeRate.GT(commissionMax) {
+		return ErrCommissionChangeRateExceedsMax(DefaultCodespace)
+	}
+	return nil
+}
+
+// update the commission rate, which must stay under the max rate and change
+// by no more than the change rate over the day
+func (v Validator) UpdateCommission(commission sdk.Rat) (Validator, sdk.Error) {
+	if commission.LT(sdk.ZeroRat()) {
+		return v, ErrCommissionNegative(DefaultCodespace)
+	}
+	if commission.GT(v.CommissionMax) {
+		return v, ErrCommissionExceedsMax(DefaultCodespace)
+	}
+
+	change := commission.Sub(v.Commission)
+	if change.LT(sdk.ZeroRat()) {
+		change = sdk.ZeroRat().Sub(change)
+	}
+	changeToday := v.CommissionChangeToday.Add(change)
+	if changeToday.GT(v.CommissionChangeRate) {
+		return v, ErrCommissionChangeTooBig(DefaultCodespace, v.CommissionChangeToday, v.CommissionChangeRate)
+	}
+
+	v.Commission = commission
+	v.CommissionChangeToday = changeToday
+	return v, nil
+}
+
 // abci validator from stake validator type
 func (v Validator) ABCIValidator() abci.Validator {
 	return abci.Validator{
@@ -278,6 +329,31 @@ func (v Validator) DelegatorShareExRate(pool Pool) sdk.Rat {
 	return eqBondedShares.Quo(v.DelegatorShares)
 }
 
+// get the amount of tokens the owner of the validator has delegated to it
+func (v Validator) SelfDelegationTokens(pool Pool) sdk.Rat {
+	return v.DelegatorShareExRate(pool).Mul(v.SelfDelegationShares).Mul(pool.BondedShareExRate())
+}
+
+// whether the owner of the validator keeps at least the minimum self-delegation
+func (v Validator) HasMinSelfDelegation(pool Pool) bool {
+	return v.SelfDelegationTokens(pool).GTE(sdk.NewRatFromInt(v.MinSelfDelegation))
+}
+
+// SelfBond - the tokens the owner of a validator keeps delegated to it, next
+// to the minimum it must keep delegated
+type SelfBond struct {
+	Tokens            sdk.Rat `json:"tokens"`
+	MinSelfDelegation sdk.Int `json:"min_self_delegation"`
+}
+
+// get the self-bond of the validator owner in tokens
+func (v Validator) SelfBond(pool Pool) SelfBond {
+	return SelfBond{
+		Tokens:            v.SelfDelegationTokens(pool),
+		MinSelfDelegation: v.MinSelfDelegation,
+	}
+}
+
 //______________________________________________________________________
 
 // ensure fulfills the sdk validator types
@@ -308,6 +384,8 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Validator: %s\n", bechVal)
 	resp += fmt.Sprintf("Shares: Status %s,  Amount: %s\n", sdk.BondStatusToString(v.PoolShares.Status), v.PoolShares.Amount.FloatString())
 	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.FloatString())
+	resp += fmt.Sprintf("Self Delegation Shares: %s\n", v.SelfDelegationShares.FloatString())
+	resp += fmt.Sprintf("Min Self Delegation: %s\n", v.MinSelfDelegation.String())
 	resp += fmt.Sprintf("Description: %s\n", v.Description)
 	resp += fmt.Sprintf("Bond Height: %d\n", v.BondHeight)
 	resp += fmt.Sprintf("Proposer Reward Pool: %s\n", v.ProposerRewardPool.String())
@@ -60,6 +60,21 @@ func TestAddTokensValidatorUnbonded(t *testing.T) {
 }
 
 // TODO refactor to make simpler like the AddToken tests above
+func TestSelfBond(t *testing.T) {
+	pool := InitialPool()
+	pool.LooseTokens = 10
+	val := NewValidator(addr1, pk1, Description{})
+	val, pool = val.UpdateStatus(pool, sdk.Bonded)
+	val, pool, delShares := val.AddTokensFromDel(pool, 10)
+	val.SelfDelegationShares = delShares.Quo(sdk.NewRat(2))
+	val.MinSelfDelegation = sdk.NewInt(3)
+
+	selfBond := val.SelfBond(pool)
+	require.True(sdk.RatEq(t, sdk.NewRat(5), selfBond.Tokens))
+	require.Equal(t, sdk.NewInt(3), selfBond.MinSelfDelegation)
+	require.True(t, val.HasMinSelfDelegation(pool))
+}
+
 func TestRemoveDelShares(t *testing.T) {
 	poolA := InitialPool()
 	poolA.LooseTokens = 10
@@ -0,0 +1,146 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/mock"
+)
+
+var keyMigrated = []byte("migrated")
+
+// initialize the mock application for this module, the migrations of the
+// test upgrade write to the data store
+func getMockApp(t *testing.T) (*mock.App, Keeper, *sdk.KVStoreKey) {
+	mapp := mock.NewApp()
+
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
+	keyData := sdk.NewKVStoreKey("data")
+	keeper := NewKeeper(mapp.Cdc, keyUpgrade, mapp.RegisterCodespace(DefaultCodespace))
+
+	mapp.SetBeginBlocker(getBeginBlocker(keeper))
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyUpgrade, keyData}))
+	mock.SetGenesis(mapp, nil)
+
+	return mapp, keeper, keyData
+}
+
+// upgrade beginblocker
+func getBeginBlocker(keeper Keeper) sdk.BeginBlocker {
+	return func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		tags := BeginBlocker(ctx, keeper)
+		return abci.ResponseBeginBlock{
+			Tags: tags.ToKVPairs(),
+		}
+	}
+}
+
+// run an empty block
+func nextBlock(mapp *mock.App, height int64) abci.ResponseBeginBlock {
+	res := mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	return res
+}
+
+func TestUpgradeHalt(t *testing.T) {
+	mapp, keeper, keyData := getMockApp(t)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1})
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{"v2", 1, "release v2"}))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{"", 3, "release v2"}))
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{"v2", 3, "release v2"}))
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+
+	// the old binary runs the blocks before the upgrade and halts at its height
+	nextBlock(mapp, 2)
+	require.Panics(t, func() {
+		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	})
+
+	// the new binary runs the migrations and goes on
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan Plan) {
+		ctx.KVStore(keyData).Set(keyMigrated, []byte(plan.Name))
+	})
+	res := mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	require.Equal(t, sdk.NewTags("upgrade", []byte("v2")).ToKVPairs(), res.Tags)
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	nextBlock(mapp, 4)
+
+	ctx = mapp.BaseApp.NewContext(true, abci.Header{Height: 4})
+	require.Equal(t, []byte("v2"), ctx.KVStore(keyData).Get(keyMigrated))
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.Equal(t, int64(3), keeper.GetDoneHeight(ctx, "v2"))
+
+	// a done upgrade is not scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{"v2", 10, "release v2"}))
+}
+
+func TestUpgradeBeforeTrigger(t *testing.T) {
+	mapp, keeper, _ := getMockApp(t)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1})
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{"v2", 5, "release v2"}))
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+
+	// the new binary refuses the blocks before the upgrade height
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan Plan) {})
+	require.Panics(t, func() {
+		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	})
+}
+
+func TestClearUpgradePlan(t *testing.T) {
+	mapp, keeper, _ := getMockApp(t)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1})
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{"v2", 3, "release v2"}))
+
+	// a new plan replaces the scheduled one
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{"v3", 4, "release v3"}))
+	plan, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, Plan{"v3", 4, "release v3"}, plan)
+
+	keeper.ClearUpgradePlan(ctx)
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+
+	// no halt once cleared
+	nextBlock(mapp, 2)
+	nextBlock(mapp, 3)
+	nextBlock(mapp, 4)
+}
+
+func TestUpgradeGenesis(t *testing.T) {
+	mapp, keeper, _ := getMockApp(t)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1})
+	require.Equal(t, DefaultGenesisState(), WriteGenesis(ctx, keeper))
+
+	keeper.setDoneHeight(ctx, "v2", 1)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{"v3", 5, "release v3"}))
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, &Plan{"v3", 5, "release v3"}, genesis.Plan)
+	require.Equal(t, []DoneUpgrade{{"v2", 1}}, genesis.Done)
+
+	// the exported state is restored by a new chain
+	mapp2, keeper2, _ := getMockApp(t)
+	mapp2.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx2 := mapp2.BaseApp.NewContext(false, abci.Header{Height: 1})
+	InitGenesis(ctx2, keeper2, genesis)
+	require.Equal(t, genesis, WriteGenesis(ctx2, keeper2))
+	require.NotNil(t, keeper2.ScheduleUpgrade(ctx2, Plan{"v2", 10, "release v2"}))
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tmlibs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// get the command to query the scheduled upgrade plan
+func GetCmdQueryPlan(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade-plan",
+		Short: "Query the scheduled software upgrade plan",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(upgrade.PlanKey, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no upgrade scheduled")
+			}
+			var plan upgrade.Plan
+			cdc.MustUnmarshalBinary(res, &plan)
+
+			switch viper.Get(cli.OutputFlag) {
+
+			case "text":
+				fmt.Println(plan.String())
+
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, plan)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
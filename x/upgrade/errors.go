@@ -0,0 +1,22 @@
+//nolint
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default upgrade codespace
+	DefaultCodespace sdk.CodespaceType = 14
+
+	CodeInvalidPlan CodeType = 101
+)
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, fmt.Sprintf("invalid upgrade plan: %s", msg))
+}
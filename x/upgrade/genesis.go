@@ -0,0 +1,47 @@
+package upgrade
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DoneUpgrade records the height at which an upgrade was done
+type DoneUpgrade struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+// GenesisState - all upgrade state that must be provided at genesis
+type GenesisState struct {
+	Plan *Plan         `json:"plan,omitempty"` // scheduled upgrade plan, if any
+	Done []DoneUpgrade `json:"done"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Done: []DoneUpgrade{},
+	}
+}
+
+// InitGenesis - store the scheduled upgrade plan and the done upgrades
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, done := range data.Done {
+		k.setDoneHeight(ctx, done.Name, done.Height)
+	}
+	if data.Plan != nil {
+		k.setPlan(ctx, *data.Plan)
+	}
+}
+
+// WriteGenesis - output the scheduled upgrade plan and the done upgrades
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var plan *Plan
+	scheduled, found := k.GetUpgradePlan(ctx)
+	if found {
+		plan = &scheduled
+	}
+	return GenesisState{
+		Plan: plan,
+		Done: k.GetDoneUpgrades(ctx),
+	}
+}
@@ -0,0 +1,115 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the upgrade store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// handlers of the upgrades known to this binary, keyed by plan name
+	upgradeHandlers map[string]UpgradeHandler
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an upgrade keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:        key,
+		cdc:             cdc,
+		upgradeHandlers: make(map[string]UpgradeHandler),
+		codespace:       codespace,
+	}
+}
+
+// SetUpgradeHandler registers the handler of an upgrade. A binary registers
+// the handler of the upgrade it implements, the chain then runs it at the
+// height of the plan instead of halting.
+func (k Keeper) SetUpgradeHandler(name string, handler UpgradeHandler) {
+	k.upgradeHandlers[name] = handler
+}
+
+// ScheduleUpgrade schedules an upgrade, replacing any plan not yet executed
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	err := plan.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("height %d has already passed", plan.Height))
+	}
+	if k.GetDoneHeight(ctx, plan.Name) != 0 {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("upgrade %s has already been done", plan.Name))
+	}
+	k.setPlan(ctx, plan)
+	return nil
+}
+
+func (k Keeper) setPlan(ctx sdk.Context, plan Plan) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(PlanKey, k.cdc.MustMarshalBinary(plan))
+}
+
+// GetUpgradePlan returns the scheduled upgrade plan if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan cancels the scheduled upgrade plan
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which an upgrade was done, zero if it
+// was not
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+	var height int64
+	k.cdc.MustUnmarshalBinary(bz, &height)
+	return height
+}
+
+// GetDoneUpgrades returns the done upgrades ordered by name
+func (k Keeper) GetDoneUpgrades(ctx sdk.Context) []DoneUpgrade {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DoneKey)
+	defer iterator.Close()
+
+	done := []DoneUpgrade{}
+	for ; iterator.Valid(); iterator.Next() {
+		var height int64
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &height)
+		done = append(done, DoneUpgrade{
+			Name:   string(iterator.Key()[len(DoneKey):]),
+			Height: height,
+		})
+	}
+	return done
+}
+
+func (k Keeper) setDone(ctx sdk.Context, name string) {
+	k.setDoneHeight(ctx, name, ctx.BlockHeight())
+}
+
+func (k Keeper) setDoneHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDoneKey(name), k.cdc.MustMarshalBinary(height))
+}
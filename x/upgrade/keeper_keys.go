@@ -0,0 +1,13 @@
+package upgrade
+
+// nolint
+var (
+	// Keys for store prefixes
+	PlanKey = []byte{0x00} // key for the scheduled upgrade plan
+	DoneKey = []byte{0x01} // prefix for each key to the height of a done upgrade
+)
+
+// get the key for the height at which an upgrade was done
+func GetDoneKey(name string) []byte {
+	return append(DoneKey, []byte(name)...)
+}
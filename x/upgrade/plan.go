@@ -0,0 +1,34 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan of a software upgrade, the chain halts at the given height until the
+// nodes run a binary with an upgrade handler of the plan name
+type Plan struct {
+	Name   string `json:"name"`   // name of the upgrade handler of the new binary
+	Height int64  `json:"height"` // height at which the upgrade happens
+	Info   string `json:"info"`   // how to get the new binary, eg. a release URL
+}
+
+// ValidateBasic checks that the plan is well formed
+func (p Plan) ValidateBasic() sdk.Error {
+	if len(p.Name) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "empty name")
+	}
+	if p.Height <= 0 {
+		return ErrInvalidPlan(DefaultCodespace, "height must be positive")
+	}
+	return nil
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("Upgrade Plan\n  Name: %s\n  Height: %d\n  Info: %s", p.Name, p.Height, p.Info)
+}
+
+// UpgradeHandler runs the state migrations of an upgrade once the chain
+// reaches the height of its plan
+type UpgradeHandler func(ctx sdk.Context, plan Plan)
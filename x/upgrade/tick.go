@@ -0,0 +1,40 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker runs the scheduled upgrade once its height is reached. If the
+// binary has no handler for the upgrade it panics, halting the node until
+// it is restarted with the new binary. A binary with the handler refuses to
+// run the blocks before the upgrade height.
+func BeginBlocker(ctx sdk.Context, k Keeper) (tags sdk.Tags) {
+	tags = sdk.NewTags()
+
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return tags
+	}
+
+	handler, ok := k.upgradeHandlers[plan.Name]
+	if ctx.BlockHeight() < plan.Height {
+		if ok {
+			panic(fmt.Sprintf("BINARY UPDATED BEFORE TRIGGER! UPGRADE %q scheduled at height %d", plan.Name, plan.Height))
+		}
+		return tags
+	}
+
+	if !ok {
+		msg := fmt.Sprintf("UPGRADE %q NEEDED at height %d: %s", plan.Name, plan.Height, plan.Info)
+		ctx.Logger().With("module", "x/upgrade").Error(msg)
+		panic(msg)
+	}
+
+	ctx.Logger().With("module", "x/upgrade").Info(fmt.Sprintf("applying upgrade %q at height %d", plan.Name, ctx.BlockHeight()))
+	handler(ctx, plan)
+	k.setDone(ctx, plan.Name)
+	k.ClearUpgradePlan(ctx)
+	return tags.AppendTag("upgrade", []byte(plan.Name))
+}